@@ -0,0 +1,290 @@
+package evengclient
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+/*
+CacheEndpoint is a group of api endpoints which share a cache ttl
+*/
+type CacheEndpoint string
+
+const (
+	// CacheEndpointStatus is the system status, see GetSystemStatus
+	CacheEndpointStatus CacheEndpoint = "status"
+	// CacheEndpointLab is the metadata of a lab, see GetLab
+	CacheEndpointLab CacheEndpoint = "lab"
+	// CacheEndpointNodes are the nodes of a lab and their interfaces, see GetNodes, GetNode and GetNodeInterfaces
+	CacheEndpointNodes CacheEndpoint = "nodes"
+	// CacheEndpointNetworks are the networks of a lab, see GetNetworks and GetNetwork
+	CacheEndpointNetworks CacheEndpoint = "networks"
+	// CacheEndpointTopology is the topology of a lab, see GetTopology
+	CacheEndpointTopology CacheEndpoint = "topology"
+	// CacheEndpointFolders are folder listings, see GetFolders and GetLabFiles
+	CacheEndpointFolders CacheEndpoint = "folders"
+	// CacheEndpointTemplates are the node templates, see GetNodeTemplates and GetNodeTemplate
+	CacheEndpointTemplates CacheEndpoint = "templates"
+	// CacheEndpointUsers are the users, see GetUsers and GetUser
+	CacheEndpointUsers CacheEndpoint = "users"
+)
+
+/*
+CacheOptions configures the response cache of WithCache. TTLs maps endpoints to the time their responses are cached,
+endpoints without ttl are not cached. A nil map uses DefaultCacheTTLs.
+*/
+type CacheOptions struct {
+	TTLs map[CacheEndpoint]time.Duration
+}
+
+/*
+DefaultCacheTTLs returns the default cache ttls, which keep frequently changing data like the node status fresh
+*/
+func DefaultCacheTTLs() map[CacheEndpoint]time.Duration {
+	return map[CacheEndpoint]time.Duration{
+		CacheEndpointStatus:    5 * time.Second,
+		CacheEndpointLab:       30 * time.Second,
+		CacheEndpointNodes:     5 * time.Second,
+		CacheEndpointNetworks:  30 * time.Second,
+		CacheEndpointTopology:  10 * time.Second,
+		CacheEndpointFolders:   30 * time.Second,
+		CacheEndpointTemplates: 10 * time.Minute,
+		CacheEndpointUsers:     30 * time.Second,
+	}
+}
+
+/*
+WithCache returns a client which shares the connection and session of c, but caches read responses. Concurrent
+identical reads are sent only once. Every write through the returned client invalidates the cached entries of the lab
+it touches, writes through c do not. Errors are never cached.
+*/
+func (c *EveNgClient) WithCache(options CacheOptions) *EveNgClient {
+	if !c.isValid() {
+		return c
+	}
+	data := *c.clientData
+	data.cache = newResponseCache(options)
+	return &EveNgClient{client{&data}}
+}
+
+/*
+InvalidateCache removes the cached responses of a lab, e.g. after it was changed by another client
+*/
+func (c *EveNgClient) InvalidateCache(labPath LabPath) {
+	if c.isValid() && c.cache != nil {
+		c.cache.invalidate(func(entry *cacheEntry) bool {
+			return entry.lab == labPath.api()
+		})
+	}
+}
+
+/*
+ClearCache removes all cached responses
+*/
+func (c *EveNgClient) ClearCache() {
+	if c.isValid() && c.cache != nil {
+		c.cache.invalidate(func(entry *cacheEntry) bool {
+			return true
+		})
+	}
+}
+
+//---------- helper functions ----------//
+
+/*
+responseCache - Caches responses of read requests by path
+*/
+type responseCache struct {
+	ttls map[CacheEndpoint]time.Duration
+
+	mutex      sync.Mutex
+	entries    map[string]*cacheEntry
+	calls      map[string]*cacheCall
+	generation uint64
+}
+
+/*
+cacheEntry - A cached response
+*/
+type cacheEntry struct {
+	response *resty.Response
+	endpoint CacheEndpoint
+	lab      string
+	expires  time.Time
+}
+
+/*
+cacheCall - A read request in flight, which concurrent identical reads wait for
+*/
+type cacheCall struct {
+	done     chan struct{}
+	response *resty.Response
+	err      error
+}
+
+/*
+cachePath - The parts of an api path relevant for caching
+*/
+type cachePath struct {
+	endpoint CacheEndpoint
+	lab      string
+	resource string
+}
+
+func newResponseCache(options CacheOptions) *responseCache {
+	ttls := options.TTLs
+	if ttls == nil {
+		ttls = DefaultCacheTTLs()
+	}
+	return &responseCache{
+		ttls:    ttls,
+		entries: make(map[string]*cacheEntry),
+		calls:   make(map[string]*cacheCall),
+	}
+}
+
+/*
+do - Serves a read request from the cache or sends it, writes are sent and invalidate the affected entries
+*/
+func (c *responseCache) do(method string, path string, fetch func() (*resty.Response, error)) (*resty.Response, error) {
+	p := parseCachePath(path)
+	if method != "GET" || p.hasSideEffects() {
+		response, err := fetch()
+		// a failed write may have changed something anyway
+		c.invalidateWrite(p)
+		return response, err
+	}
+	ttl := c.ttls[p.endpoint]
+	if p.endpoint == "" || ttl <= 0 {
+		return fetch()
+	}
+
+	c.mutex.Lock()
+	if entry, ok := c.entries[path]; ok {
+		if time.Now().Before(entry.expires) {
+			c.mutex.Unlock()
+			return entry.response, nil
+		}
+		delete(c.entries, path)
+	}
+	if call, ok := c.calls[path]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		return call.response, call.err
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	c.calls[path] = call
+	generation := c.generation
+	c.mutex.Unlock()
+
+	call.response, call.err = fetch()
+
+	c.mutex.Lock()
+	if c.calls[path] == call {
+		delete(c.calls, path)
+	}
+	// the response may be outdated if a write invalidated the cache while it was in flight
+	if call.err == nil && generation == c.generation {
+		c.entries[path] = &cacheEntry{response: call.response, endpoint: p.endpoint, lab: p.lab, expires: time.Now().Add(ttl)}
+	}
+	c.mutex.Unlock()
+	close(call.done)
+	return call.response, call.err
+}
+
+/*
+invalidateWrite - Invalidates the entries a write may have changed
+*/
+func (c *responseCache) invalidateWrite(p cachePath) {
+	switch {
+	case p.lab != "":
+		labChanged := p.resource == "" || p.resource == "move"
+		nodesChanged := strings.HasPrefix(p.resource, "nodes")
+		c.invalidate(func(entry *cacheEntry) bool {
+			return entry.lab == p.lab ||
+				(labChanged && entry.endpoint == CacheEndpointFolders) ||
+				(nodesChanged && entry.endpoint == CacheEndpointStatus)
+		})
+	case p.endpoint == CacheEndpointFolders || p.endpoint == CacheEndpointUsers:
+		c.invalidate(func(entry *cacheEntry) bool {
+			return entry.endpoint == p.endpoint
+		})
+	default:
+		// e.g. adding or importing labs, logging in or out
+		c.invalidate(func(entry *cacheEntry) bool {
+			return true
+		})
+	}
+}
+
+/*
+invalidate - Removes matching entries and detaches requests in flight, so later reads are sent again
+*/
+func (c *responseCache) invalidate(match func(entry *cacheEntry) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	for path, entry := range c.entries {
+		if match(entry) {
+			delete(c.entries, path)
+		}
+	}
+	c.calls = make(map[string]*cacheCall)
+}
+
+/*
+parseCachePath - Determines the endpoint and lab of an api path like "api/labs/Folder/Lab.unl/nodes/1"
+*/
+func parseCachePath(path string) cachePath {
+	p := strings.TrimPrefix(path, endpointPath)
+	switch {
+	case p == "status":
+		return cachePath{endpoint: CacheEndpointStatus}
+	case p == "folders" || strings.HasPrefix(p, "folders/"):
+		return cachePath{endpoint: CacheEndpointFolders}
+	case strings.HasPrefix(p, "list/templates"):
+		return cachePath{endpoint: CacheEndpointTemplates}
+	case p == "users" || strings.HasPrefix(p, "users/"):
+		return cachePath{endpoint: CacheEndpointUsers}
+	case !strings.HasPrefix(p, "labs/"):
+		return cachePath{}
+	}
+	p = strings.TrimPrefix(p, "labs/")
+	i := strings.Index(p+"/", labFileSuffix+"/")
+	if i < 0 {
+		return cachePath{}
+	}
+	lab := p[:i+len(labFileSuffix)]
+	resource := strings.TrimPrefix(p[len(lab):], "/")
+	endpoint := CacheEndpoint("")
+	switch strings.SplitN(resource, "/", 2)[0] {
+	case "":
+		endpoint = CacheEndpointLab
+	case "nodes":
+		endpoint = CacheEndpointNodes
+	case "networks":
+		endpoint = CacheEndpointNetworks
+	case "topology":
+		endpoint = CacheEndpointTopology
+	}
+	return cachePath{endpoint: endpoint, lab: lab, resource: resource}
+}
+
+/*
+hasSideEffects - Checks whether a GET request changes the lab, which is the case for starting, stopping and wiping
+nodes
+*/
+func (p cachePath) hasSideEffects() bool {
+	if p.endpoint != CacheEndpointNodes {
+		return false
+	}
+	for _, action := range []string{"/start", "/stop", "/wipe"} {
+		if strings.Contains(p.resource, action) {
+			return true
+		}
+	}
+	return false
+}
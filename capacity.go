@@ -0,0 +1,181 @@
+package evengclient
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+ServerCapacity describes the hardware of an eve-ng server, which the api does not report. RAM is given in MB.
+MaxMemoryPercent is the memory usage starting a lab must not exceed and defaults to 90.
+*/
+type ServerCapacity struct {
+	RAM              int
+	CPUs             int
+	MaxMemoryPercent int
+}
+
+/*
+ResourceRequirements are the resources needed to start a number of nodes. RAM is given in MB.
+*/
+type ResourceRequirements struct {
+	Nodes int
+	RAM   int
+	CPU   int
+}
+
+/*
+CapacityEstimate is the result of checking whether a server can start a lab. AvailableRAM is the memory in MB
+which can be used before MaxMemoryPercent is reached.
+*/
+type CapacityEstimate struct {
+	Required     ResourceRequirements
+	Status       SystemStatus
+	AvailableRAM int
+	CanStart     bool
+	Warnings     []string
+}
+
+/*
+InsufficientCapacityError - Is returned by PreflightStartLab when the server does not have enough memory to start a lab
+*/
+type InsufficientCapacityError struct {
+	LabPath  LabPath
+	Estimate CapacityEstimate
+}
+
+func (e *InsufficientCapacityError) Error() string {
+	return "not enough memory to start lab " + e.LabPath.String() + ": " + strconv.Itoa(e.Estimate.Required.RAM) + " MB required, " + strconv.Itoa(e.Estimate.AvailableRAM) + " MB available"
+}
+
+/*
+Requirements returns the resources needed to start all nodes which are not running
+*/
+func (n Nodes) Requirements() ResourceRequirements {
+	var required ResourceRequirements
+	for _, node := range n {
+		if node.Status != NodeStatusStopped {
+			continue
+		}
+		required.Nodes++
+		required.RAM += node.RAM
+		required.CPU += node.CPU
+	}
+	return required
+}
+
+/*
+Requirements returns the resources needed to start all nodes of the spec
+*/
+func (s LabSpec) Requirements() ResourceRequirements {
+	var required ResourceRequirements
+	for _, node := range s.Nodes {
+		count := node.Count
+		if count <= 0 {
+			count = 1
+		}
+		required.Nodes += count
+		required.RAM += count * node.RAM
+		required.CPU += count * node.CPU
+	}
+	return required
+}
+
+/*
+Estimate checks whether a server with the given status can start nodes with the given requirements. Only memory
+decides whether the nodes can be started, as qemu happily overcommits cpus; cpu, swap and disk usage are reported
+as warnings. An error is returned if the RAM of the server is not set, as the available memory cannot be estimated
+without it.
+*/
+func (s ServerCapacity) Estimate(status SystemStatus, required ResourceRequirements) (CapacityEstimate, error) {
+	if s.RAM <= 0 {
+		return CapacityEstimate{Required: required, Status: status}, errors.New("server capacity has no ram set")
+	}
+	maxMemoryPercent := s.MaxMemoryPercent
+	if maxMemoryPercent <= 0 || maxMemoryPercent > 100 {
+		maxMemoryPercent = 90
+	}
+	estimate := CapacityEstimate{
+		Required:     required,
+		Status:       status,
+		AvailableRAM: s.RAM * (maxMemoryPercent - status.Mem) / 100,
+	}
+	if estimate.AvailableRAM < 0 {
+		estimate.AvailableRAM = 0
+	}
+	estimate.CanStart = required.RAM <= estimate.AvailableRAM
+
+	if s.CPUs > 0 && required.CPU > s.CPUs {
+		estimate.Warnings = append(estimate.Warnings, "nodes need "+strconv.Itoa(required.CPU)+" cpus, server has "+strconv.Itoa(s.CPUs))
+	}
+	if status.CPU >= 90 {
+		estimate.Warnings = append(estimate.Warnings, "cpu usage is at "+strconv.Itoa(status.CPU)+"%")
+	}
+	if status.Swap >= 10 {
+		estimate.Warnings = append(estimate.Warnings, "swap usage is at "+strconv.Itoa(status.Swap)+"%")
+	}
+	if status.Disk >= 90 {
+		estimate.Warnings = append(estimate.Warnings, "disk usage is at "+strconv.Itoa(status.Disk)+"%")
+	}
+	return estimate, nil
+}
+
+/*
+EstimateLabCapacity checks whether the server can start all nodes of the lab which are not running yet
+*/
+func (c *EveNgClient) EstimateLabCapacity(labPath LabPath, capacity ServerCapacity) (CapacityEstimate, error) {
+	nodes, err := c.GetNodes(labPath)
+	if err != nil {
+		return CapacityEstimate{}, errors.Wrap(err, "error while retrieving nodes")
+	}
+	return c.estimateCapacity(capacity, nodes.Requirements())
+}
+
+/*
+EstimateLabSpecCapacity checks whether the server can start all nodes of the lab spec
+*/
+func (c *EveNgClient) EstimateLabSpecCapacity(spec LabSpec, capacity ServerCapacity) (CapacityEstimate, error) {
+	return c.estimateCapacity(capacity, spec.Requirements())
+}
+
+/*
+PreflightStartLab starts all nodes of the lab which are not running if the server has enough memory for them.
+Otherwise nothing is started and an InsufficientCapacityError is returned.
+*/
+func (c *EveNgClient) PreflightStartLab(labPath LabPath, capacity ServerCapacity) (CapacityEstimate, error) {
+	nodes, err := c.GetNodes(labPath)
+	if err != nil {
+		return CapacityEstimate{}, errors.Wrap(err, "error while retrieving nodes")
+	}
+	estimate, err := c.estimateCapacity(capacity, nodes.Requirements())
+	if err != nil {
+		return estimate, err
+	}
+	if !estimate.CanStart {
+		return estimate, &InsufficientCapacityError{LabPath: labPath, Estimate: estimate}
+	}
+	for _, node := range nodes {
+		if node.Status != NodeStatusStopped {
+			continue
+		}
+		err = c.StartNode(labPath, node.ID)
+		if err != nil {
+			return estimate, errors.Wrap(err, "error while starting node "+node.Name)
+		}
+	}
+	return estimate, nil
+}
+
+//---------- helper functions ----------//
+
+/*
+estimateCapacity - Estimates the capacity for the given requirements using the current system status
+*/
+func (c *EveNgClient) estimateCapacity(capacity ServerCapacity, required ResourceRequirements) (CapacityEstimate, error) {
+	status, err := c.GetSystemStatus()
+	if err != nil {
+		return CapacityEstimate{}, errors.Wrap(err, "error while retrieving system status")
+	}
+	return capacity.Estimate(status, required)
+}
@@ -0,0 +1,299 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+DefaultTemplateCatalogTTL is the time a template catalog is cached if no ttl is given
+*/
+const DefaultTemplateCatalogTTL = 10 * time.Minute
+
+/*
+CatalogTemplate is a template of a TemplateCatalog. Vendor is the first word of the description, e.g. "Cisco" for
+"Cisco ASAv".
+*/
+type CatalogTemplate struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Vendor      string    `json:"vendor"`
+	Type        string    `json:"type"`
+	Images      ImageList `json:"images"`
+	Template    Template  `json:"template"`
+}
+
+/*
+HasImages checks whether the template has images installed
+*/
+func (t CatalogTemplate) HasImages() bool {
+	return len(t.Images) > 0
+}
+
+/*
+TemplateQuery filters the templates of a catalog. Vendor and Description match case insensitive substrings, Type
+matches exactly. Empty fields match all templates.
+*/
+type TemplateQuery struct {
+	Vendor      string
+	Description string
+	Type        string
+	WithImages  bool
+}
+
+/*
+TemplateNotFoundError - Is returned when a catalog does not contain the requested template
+*/
+type TemplateNotFoundError struct {
+	Name string
+}
+
+func (e *TemplateNotFoundError) Error() string {
+	return "template " + e.Name + " not found"
+}
+
+/*
+TemplateCatalog caches all templates of a server together with their installed images. The catalog is loaded on
+first use and reloaded when it is older than its ttl. It is safe for concurrent use.
+*/
+type TemplateCatalog struct {
+	client *EveNgClient
+	ttl    time.Duration
+
+	mutex     sync.Mutex
+	loaded    time.Time
+	templates []CatalogTemplate
+}
+
+/*
+templateCatalogFile - The json representation of a template catalog
+*/
+type templateCatalogFile struct {
+	Loaded    time.Time         `json:"loaded"`
+	Templates []CatalogTemplate `json:"templates"`
+}
+
+/*
+NewTemplateCatalog creates a template catalog of the server, which is cached for ttl. A ttl of 0 uses
+DefaultTemplateCatalogTTL.
+*/
+func (c *EveNgClient) NewTemplateCatalog(ttl time.Duration) *TemplateCatalog {
+	if ttl <= 0 {
+		ttl = DefaultTemplateCatalogTTL
+	}
+	return &TemplateCatalog{client: c, ttl: ttl}
+}
+
+/*
+ReadTemplateCatalog reads a catalog written by WriteJSON. The catalog is not connected to a server and never expires.
+*/
+func ReadTemplateCatalog(r io.Reader) (*TemplateCatalog, error) {
+	var file templateCatalogFile
+	err := json.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal template catalog")
+	}
+	return &TemplateCatalog{loaded: file.Loaded, templates: file.Templates}, nil
+}
+
+/*
+Templates returns all templates sorted by name
+*/
+func (t *TemplateCatalog) Templates() ([]CatalogTemplate, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	err := t.load(false)
+	if err != nil {
+		return nil, err
+	}
+	return append([]CatalogTemplate(nil), t.templates...), nil
+}
+
+/*
+Get returns the template with the given name, a TemplateNotFoundError is returned if the server has no such template
+*/
+func (t *TemplateCatalog) Get(name string) (CatalogTemplate, error) {
+	templates, err := t.Templates()
+	if err != nil {
+		return CatalogTemplate{}, err
+	}
+	i := sort.Search(len(templates), func(i int) bool {
+		return templates[i].Name >= name
+	})
+	if i == len(templates) || templates[i].Name != name {
+		return CatalogTemplate{}, &TemplateNotFoundError{Name: name}
+	}
+	return templates[i], nil
+}
+
+/*
+Search returns all templates matching the query sorted by name
+*/
+func (t *TemplateCatalog) Search(query TemplateQuery) ([]CatalogTemplate, error) {
+	templates, err := t.Templates()
+	if err != nil {
+		return nil, err
+	}
+	var matches []CatalogTemplate
+	for _, template := range templates {
+		if query.matches(template) {
+			matches = append(matches, template)
+		}
+	}
+	return matches, nil
+}
+
+/*
+WithImages returns all templates which have images installed
+*/
+func (t *TemplateCatalog) WithImages() ([]CatalogTemplate, error) {
+	return t.Search(TemplateQuery{WithImages: true})
+}
+
+/*
+ValidateNodeSpec validates a node spec against its cached template, see Template.ValidateNodeSpec
+*/
+func (t *TemplateCatalog) ValidateNodeSpec(spec NodeSpec) (NodeSpec, error) {
+	if spec.Template == "" {
+		return spec, NodeSpecErrors{{Field: "template", Message: "no template given"}}
+	}
+	template, err := t.Get(spec.Template)
+	if err != nil {
+		if _, ok := err.(*TemplateNotFoundError); ok {
+			return spec, NodeSpecErrors{{Field: "template", Value: spec.Template, Message: "template does not exist"}}
+		}
+		return spec, err
+	}
+	return template.Template.ValidateNodeSpec(spec)
+}
+
+/*
+Refresh reloads the catalog from the server regardless of its age
+*/
+func (t *TemplateCatalog) Refresh() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.load(true)
+}
+
+/*
+Loaded returns the time the catalog was loaded, which is zero if it was not loaded yet
+*/
+func (t *TemplateCatalog) Loaded() time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.loaded
+}
+
+/*
+WriteJSON writes the catalog as json, loading it first if necessary. The output can be read with ReadTemplateCatalog.
+*/
+func (t *TemplateCatalog) WriteJSON(w io.Writer) error {
+	templates, err := t.Templates()
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(templateCatalogFile{Loaded: t.Loaded(), Templates: templates})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal template catalog")
+	}
+	return nil
+}
+
+//---------- helper functions ----------//
+
+/*
+load - Loads the templates unless the cached templates are still valid, the mutex must be held
+*/
+func (t *TemplateCatalog) load(force bool) error {
+	if t.client == nil {
+		if t.templates == nil {
+			return errors.New("template catalog is not connected to a server")
+		}
+		return nil
+	}
+	if !force && t.templates != nil && time.Since(t.loaded) < t.ttl {
+		return nil
+	}
+	if !t.client.isValid() {
+		return &NotValidError{}
+	}
+	list, err := t.client.GetNodeTemplates()
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving templates")
+	}
+	// templates without installed images are not fetched, like in GetAvailableImages
+	var names []string
+	for name, description := range list {
+		if !strings.HasSuffix(description, templateMissingSuffix) {
+			names = append(names, name)
+		}
+	}
+	details, err := t.client.getNodeTemplates(names)
+	if err != nil {
+		return err
+	}
+
+	templates := make([]CatalogTemplate, 0, len(list))
+	for name, description := range list {
+		template := details[name]
+		description = strings.TrimSuffix(description, templateMissingSuffix)
+		images := template.Options.Image.List
+		if images == nil {
+			images = ImageList{}
+		}
+		templates = append(templates, CatalogTemplate{
+			Name:        name,
+			Description: description,
+			Vendor:      templateVendor(description),
+			Type:        template.Type,
+			Images:      images,
+			Template:    template,
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	t.templates = templates
+	t.loaded = time.Now()
+	return nil
+}
+
+/*
+matches - Checks whether a template matches the query
+*/
+func (q TemplateQuery) matches(template CatalogTemplate) bool {
+	if q.Vendor != "" && !containsFold(template.Vendor, q.Vendor) {
+		return false
+	}
+	if q.Description != "" && !containsFold(template.Description, q.Description) {
+		return false
+	}
+	if q.Type != "" && template.Type != q.Type {
+		return false
+	}
+	return !q.WithImages || template.HasImages()
+}
+
+/*
+templateVendor - Derives the vendor of a template from its description
+*/
+func templateVendor(description string) string {
+	fields := strings.Fields(description)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
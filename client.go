@@ -1,6 +1,8 @@
 package evengclient
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/url"
 	"reflect"
@@ -9,7 +11,6 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 )
 
 const (
@@ -31,6 +32,11 @@ type clientData struct {
 
 	resty   *resty.Client
 	useAuth bool
+
+	cache    *responseCache
+	limits   *requestLimits
+	observer *requestObserver
+	ctx      context.Context
 }
 
 /*
@@ -49,6 +55,16 @@ func (c *client) isValid() bool {
 	return c.clientData != nil
 }
 
+/*
+context - Returns the context of the client's requests
+*/
+func (c *client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 /*
 SetUsernameAndPassword - Is used to set a username and password for https auth
 */
@@ -68,25 +84,6 @@ func (c *client) SetUsernameAndPassword(username, password string) error {
 	return nil
 }
 
-/*
-init is used for initialising the config file
-*/
-func init() {
-	// Search config in home directory with name "eve-ng-api" (without extension).
-	viper.AddConfigPath("config/")
-	viper.SetConfigType("yaml")
-	viper.SetConfigName("eve-ng-api")
-
-	//Set env var prefix to only match certain vars
-	viper.SetEnvPrefix("EVE_NG_API")
-
-	// read in environment variables that match
-	viper.AutomaticEnv()
-
-	// If a config file is found, read it in.
-	viper.ReadInConfig()
-}
-
 /*
 request - Is used to send either GET, POST, PUT or DELETE requests
 */
@@ -106,6 +103,46 @@ func (c *client) request(method string, path string, body string, header, queryP
 		request.SetBody(body)
 	}
 
+	return c.execute(request, method, path)
+}
+
+/*
+upload - Is used to send a multipart POST request containing a file
+*/
+func (c *client) upload(path string, formData map[string]string, fileParam, fileName, contentType string, file []byte) (*resty.Response, error) {
+	request := c.resty.R()
+	if formData != nil {
+		request.SetFormData(formData)
+	}
+	request.SetMultipartField(fileParam, fileName, contentType, bytes.NewReader(file))
+	return c.execute(request, "POST", path)
+}
+
+/*
+execute - Sends a prepared request through the response cache, if the client has one
+*/
+func (c *client) execute(request *resty.Request, method string, path string) (*resty.Response, error) {
+	if c.cache != nil {
+		return c.cache.do(method, path, func() (*resty.Response, error) {
+			return c.send(request, method, path)
+		})
+	}
+	return c.send(request, method, path)
+}
+
+/*
+send - Sends a prepared request and checks the response status
+*/
+func (c *client) send(request *resty.Request, method string, path string) (*resty.Response, error) {
+	ctx := c.context()
+	release, err := c.limits.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	request.SetContext(ctx)
+	trace := c.observer.start(request, method, path)
+
 	if c.useAuth {
 		request.SetBasicAuth(c.username, c.password)
 	}
@@ -113,9 +150,6 @@ func (c *client) request(method string, path string, body string, header, queryP
 	var response *resty.Response
 	response = nil
 
-	var err error
-	err = nil
-
 	switch method {
 	case "GET":
 		response, err = request.Get(c.baseURL + urlEscapePath(path))
@@ -126,16 +160,23 @@ func (c *client) request(method string, path string, body string, header, queryP
 	case "DELETE":
 		response, err = request.Delete(c.baseURL + urlEscapePath(path))
 	default:
-		return nil, errors.New("invalid http method: " + method)
+		err = errors.New("invalid http method: " + method)
+		trace.finish(nil, err)
+		return nil, err
 	}
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http request")
+		err = errors.Wrap(err, "error during http "+method+" request to "+path)
+		trace.finish(response, err)
+		return nil, err
 	}
 	if response.StatusCode() != 200 && response.StatusCode() != 201 {
-		return nil, errors.Wrap(getHTTPError(response), "http request responded with an error")
+		err = errors.Wrap(getHTTPError(response), "http "+method+" request to "+path+" responded with an error")
+		trace.finish(response, err)
+		return nil, err
 	}
+	trace.finish(response, nil)
 
-	return response, err
+	return response, nil
 }
 
 //Http error handling
@@ -179,6 +220,14 @@ func getHTTPError(response *resty.Response) error {
 	return httpError
 }
 
+/*
+isNotFoundError - Checks whether the cause of an error is an http 404 response of the api
+*/
+func isNotFoundError(err error) bool {
+	httpError, ok := errors.Cause(err).(HTTPError)
+	return ok && httpError.StatusCode == 404
+}
+
 //---------- helper functions ----------//
 
 /*
@@ -218,7 +267,7 @@ urlEscapePath - Escapes special characters of a given url path
 func urlEscapePath(unescaped string) string {
 	arr := strings.Split(unescaped, "/")
 	for i, partString := range strings.Split(unescaped, "/") {
-		arr[i] = url.QueryEscape(partString)
+		arr[i] = url.PathEscape(partString)
 	}
 	return strings.Join(arr, "/")
 }
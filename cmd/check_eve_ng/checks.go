@@ -0,0 +1,141 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+)
+
+/*
+checkSystem - Checks cpu, memory, swap and disk usage in percent
+*/
+func checkSystem(client *evengclient.EveNgClient, warning *threshold, critical *threshold) (*result, error) {
+	status, err := client.GetSystemStatus()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving system status")
+	}
+	r := &result{}
+	for _, usage := range []struct {
+		name  string
+		value int
+	}{
+		{"cpu", status.CPU},
+		{"mem", status.Mem},
+		{"swap", status.Swap},
+		{"disk", status.Disk},
+	} {
+		r.check(usage.name+" "+strconv.Itoa(usage.value)+"%", float64(usage.value), warning, critical)
+		r.perf(usage.name, float64(usage.value), "%", warning, critical, "0", "100")
+	}
+	r.perf("qemu", float64(status.Qemu), "", nil, nil, "0", "")
+	r.perf("iol", float64(status.Iol), "", nil, nil, "0", "")
+	r.perf("dynamips", float64(status.Dynamips), "", nil, nil, "0", "")
+	return r, nil
+}
+
+/*
+checkNodes - Checks that the given node, or all nodes of the lab if no node is given, are running. The thresholds
+apply to the number of nodes which are not running.
+*/
+func checkNodes(client *evengclient.EveNgClient, labPath evengclient.LabPath, nodeName string, warning *threshold, critical *threshold) (*result, error) {
+	nodes, err := client.GetNodes(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving nodes of lab "+labPath.String())
+	}
+	var checked []evengclient.NodeWithID
+	for _, node := range nodes {
+		if nodeName == "" || node.Name == nodeName || strconv.Itoa(node.ID) == nodeName {
+			checked = append(checked, node)
+		}
+	}
+	if len(checked) == 0 {
+		if nodeName != "" {
+			return nil, errors.New("lab " + labPath.String() + " has no node '" + nodeName + "'")
+		}
+		return nil, errors.New("lab " + labPath.String() + " has no nodes")
+	}
+
+	var stopped []string
+	for _, node := range checked {
+		if node.Status != evengclient.NodeStatusRunning {
+			stopped = append(stopped, node.Name)
+		}
+	}
+	sort.Strings(stopped)
+	r := &result{}
+	message := strconv.Itoa(len(checked)-len(stopped)) + " of " + strconv.Itoa(len(checked)) + " nodes running"
+	if len(stopped) > 0 {
+		message += ", not running: " + strings.Join(stopped, ", ")
+	}
+	r.check(message, float64(len(stopped)), warning, critical)
+	r.perf("running", float64(len(checked)-len(stopped)), "", nil, nil, "0", strconv.Itoa(len(checked)))
+	r.perf("not_running", float64(len(stopped)), "", warning, critical, "0", strconv.Itoa(len(checked)))
+	return r, nil
+}
+
+/*
+checkLab - Checks that the lab can be loaded, the thresholds apply to the response time in seconds
+*/
+func checkLab(client *evengclient.EveNgClient, labPath evengclient.LabPath, warning *threshold, critical *threshold) (*result, error) {
+	start := time.Now()
+	lab, err := client.GetLab(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving lab "+labPath.String())
+	}
+	nodes, err := client.GetNodes(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving nodes of lab "+labPath.String())
+	}
+	elapsed := time.Since(start).Seconds()
+
+	r := &result{}
+	r.check("lab '"+lab.Name+"' with "+strconv.Itoa(len(nodes))+" nodes loaded in "+strconv.FormatFloat(elapsed, 'f', 3, 64)+"s", elapsed, warning, critical)
+	r.perf("time", elapsed, "s", warning, critical, "0", "")
+	r.perf("nodes", float64(len(nodes)), "", nil, nil, "0", "")
+	return r, nil
+}
+
+/*
+checkUserExpiration - Checks the days until the given user, or every user if no user is given, expires. Users
+which never expire are always ok.
+*/
+func checkUserExpiration(client *evengclient.EveNgClient, username string, warning *threshold, critical *threshold, now time.Time) (*result, error) {
+	users, err := client.GetUsers()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving users")
+	}
+	var usernames []string
+	for name := range users {
+		if username == "" || name == username {
+			usernames = append(usernames, name)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil, errors.New("no user '" + username + "' found")
+	}
+	sort.Strings(usernames)
+
+	r := &result{}
+	never := 0
+	for _, name := range usernames {
+		expiration, err := strconv.ParseInt(users[name].Expiration, 10, 64)
+		if err != nil || expiration <= 0 {
+			never++
+			continue
+		}
+		days := math.Round(time.Unix(expiration, 0).Sub(now).Hours()/24*100) / 100
+		if critical.alerts(days) || warning.alerts(days) || username != "" {
+			r.check("user "+name+" expires in "+strconv.FormatFloat(days, 'f', 1, 64)+" days", days, warning, critical)
+		}
+		r.perf(name, days, "d", warning, critical, "", "")
+	}
+	if len(r.messages) == 0 {
+		r.add(stateOK, strconv.Itoa(len(usernames))+" users checked, "+strconv.Itoa(never)+" never expire")
+	}
+	return r, nil
+}
@@ -0,0 +1,132 @@
+/*
+Command check_eve_ng is a nagios/icinga plugin which checks the health of an eve-ng server.
+
+Modes:
+
+	system           cpu, memory, swap and disk usage in percent (default thresholds: -w 80 -c 90)
+	node             nodes of -lab, or only -node, are running; thresholds apply to the number of nodes not running (default: -c 0)
+	lab              -lab can be loaded; thresholds apply to the response time in seconds (default: -w 5 -c 10)
+	user-expiration  days until -user, or every user, expires (default: -w 14: -c 3:)
+
+Thresholds use the nagios range format. Url, username and password default to the EVE_NG_API_BASEURL,
+EVE_NG_API_USERNAME and EVE_NG_API_PASSWORD environment variables. The exit code is 0 (ok), 1 (warning),
+2 (critical) or 3 (unknown).
+
+	check_eve_ng -url https://eve-ng.example.com -mode node -lab /Demo/Core.unl -node R1
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+)
+
+var defaultThresholds = map[string][2]string{
+	"system":          {"80", "90"},
+	"node":            {"", "0"},
+	"lab":             {"5", "10"},
+	"user-expiration": {"14:", "3:"},
+}
+
+func main() {
+	mode, r := run()
+	fmt.Println(r.output(mode))
+	os.Exit(r.exitCode())
+}
+
+/*
+run - Parses the flags and runs the check, every failure is returned as result
+*/
+func run() (string, *result) {
+	baseURL := flag.String("url", os.Getenv("EVE_NG_API_BASEURL"), "base url of the eve-ng server")
+	username := flag.String("username", os.Getenv("EVE_NG_API_USERNAME"), "eve-ng username")
+	// the password is read from the environment after parsing, flag would print it as default value in the usage
+	password := flag.String("password", "", "eve-ng password (default $EVE_NG_API_PASSWORD)")
+	mode := flag.String("mode", "system", "check mode: system, node, lab or user-expiration")
+	warning := flag.String("w", "", "warning threshold (nagios range format)")
+	critical := flag.String("c", "", "critical threshold (nagios range format)")
+	lab := flag.String("lab", "", "lab path for the node and lab modes")
+	node := flag.String("node", "", "node name or id for the node mode, all nodes if empty")
+	user := flag.String("user", "", "username for the user-expiration mode, all users if empty")
+	timeout := flag.Duration("timeout", 30*time.Second, "time after which the check is aborted as unknown")
+	flag.Parse()
+	if *password == "" {
+		*password = os.Getenv("EVE_NG_API_PASSWORD")
+	}
+
+	defaults, ok := defaultThresholds[*mode]
+	if !ok {
+		return *mode, failed(stateUnknown, "unknown mode '"+*mode+"'")
+	}
+	if *warning == "" {
+		*warning = defaults[0]
+	}
+	if *critical == "" {
+		*critical = defaults[1]
+	}
+	warningThreshold, err := parseThreshold(*warning)
+	if err != nil {
+		return *mode, failed(stateUnknown, err.Error())
+	}
+	criticalThreshold, err := parseThreshold(*critical)
+	if err != nil {
+		return *mode, failed(stateUnknown, err.Error())
+	}
+	var labPath evengclient.LabPath
+	if *mode == "node" || *mode == "lab" {
+		labPath, err = evengclient.ParseLabPath(*lab)
+		if err != nil {
+			return *mode, failed(stateUnknown, err.Error())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	timedOut := func() *result {
+		return failed(stateUnknown, "check timed out after "+timeout.String())
+	}
+
+	client, err := evengclient.NewEveNgClient(*baseURL)
+	if err != nil {
+		return *mode, failed(stateUnknown, err.Error())
+	}
+	client = client.WithContext(ctx)
+	err = client.SetUsernameAndPassword(*username, *password)
+	if err != nil {
+		return *mode, failed(stateUnknown, err.Error())
+	}
+	err = client.Login()
+	if ctx.Err() != nil {
+		return *mode, timedOut()
+	}
+	if err != nil {
+		return *mode, failed(stateCritical, "login failed: "+err.Error())
+	}
+	defer func() {
+		_ = client.Logout()
+	}()
+
+	var r *result
+	switch *mode {
+	case "system":
+		r, err = checkSystem(client, warningThreshold, criticalThreshold)
+	case "node":
+		r, err = checkNodes(client, labPath, *node, warningThreshold, criticalThreshold)
+	case "lab":
+		r, err = checkLab(client, labPath, warningThreshold, criticalThreshold)
+	case "user-expiration":
+		r, err = checkUserExpiration(client, *user, warningThreshold, criticalThreshold, time.Now())
+	}
+	if ctx.Err() != nil {
+		return *mode, timedOut()
+	}
+	if err != nil {
+		return *mode, failed(stateUnknown, err.Error())
+	}
+	return *mode, r
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+state - A nagios plugin state, which is also the exit code of the plugin
+*/
+type state int
+
+const (
+	stateOK state = iota
+	stateWarning
+	stateCritical
+	stateUnknown
+)
+
+func (s state) String() string {
+	return [...]string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}[s]
+}
+
+/*
+result - The state, messages and performance data of a check
+*/
+type result struct {
+	state    state
+	messages []string
+	perfdata []string
+}
+
+/*
+failed - Returns a result with a single message and no performance data
+*/
+func failed(s state, message string) *result {
+	r := &result{}
+	r.add(s, message)
+	return r
+}
+
+/*
+exitCode - Returns the exit code of the plugin, which is the nagios state
+*/
+func (r *result) exitCode() int {
+	return int(r.state)
+}
+
+/*
+add - Adds a message and raises the state of the result if the given state is worse
+*/
+func (r *result) add(s state, message string) {
+	if s > r.state {
+		r.state = s
+	}
+	r.messages = append(r.messages, message)
+}
+
+/*
+check - Adds a message for a value and raises the state according to the thresholds
+*/
+func (r *result) check(message string, value float64, warning *threshold, critical *threshold) {
+	switch {
+	case critical.alerts(value):
+		r.add(stateCritical, message+" (critical)")
+	case warning.alerts(value):
+		r.add(stateWarning, message+" (warning)")
+	default:
+		r.add(stateOK, message)
+	}
+}
+
+/*
+perf - Adds performance data in the format label=value[unit];[warn];[crit];[min];[max]
+*/
+func (r *result) perf(label string, value float64, unit string, warning *threshold, critical *threshold, min string, max string) {
+	if strings.ContainsAny(label, " '=") {
+		label = "'" + strings.Replace(label, "'", "''", -1) + "'"
+	}
+	r.perfdata = append(r.perfdata, label+"="+strconv.FormatFloat(value, 'f', -1, 64)+unit+";"+warning.String()+";"+critical.String()+";"+min+";"+max)
+}
+
+/*
+output - Returns the plugin output line
+*/
+func (r *result) output(name string) string {
+	out := fmt.Sprintf("EVE-NG %s %s - %s", strings.ToUpper(name), r.state, strings.Join(r.messages, ", "))
+	if len(r.perfdata) > 0 {
+		out += " | " + strings.Join(r.perfdata, " ")
+	}
+	return out
+}
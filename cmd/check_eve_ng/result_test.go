@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+TestResult covers:
+	- result.check
+	- result.exitCode
+	- result.output
+	- failed
+*/
+func TestResult(t *testing.T) {
+	warning, err := parseThreshold("80")
+	if !assert.NoError(t, err, "Error during parseThreshold operation") {
+		return
+	}
+	critical, err := parseThreshold("90")
+	if !assert.NoError(t, err, "Error during parseThreshold operation") {
+		return
+	}
+
+	tests := []struct {
+		name     string
+		values   []float64
+		state    state
+		exitCode int
+	}{
+		{name: "ok", values: []float64{10, 80}, state: stateOK, exitCode: 0},
+		{name: "warning", values: []float64{10, 85}, state: stateWarning, exitCode: 1},
+		{name: "critical", values: []float64{95, 85}, state: stateCritical, exitCode: 2},
+	}
+	for _, test := range tests {
+		r := &result{}
+		for _, value := range test.values {
+			r.check("value", value, warning, critical)
+		}
+		assert.Equal(t, test.state, r.state, "State of "+test.name+" result does not match expected value")
+		assert.Equal(t, test.exitCode, r.exitCode(), "Exit code of "+test.name+" result does not match expected value")
+	}
+
+	r := failed(stateUnknown, "check timed out after 30s")
+	assert.Equal(t, 3, r.exitCode(), "Exit code of unknown result does not match expected value")
+	assert.Equal(t, "EVE-NG SYSTEM UNKNOWN - check timed out after 30s", r.output("system"), "Output of unknown result does not match expected value")
+
+	r = &result{}
+	r.check("cpu 85%", 85, warning, critical)
+	r.perf("cpu", 85, "%", warning, critical, "0", "100")
+	assert.Equal(t, "EVE-NG SYSTEM WARNING - cpu 85% (warning) | cpu=85%;80;90;0;100", r.output("system"), "Output with performance data does not match expected value")
+}
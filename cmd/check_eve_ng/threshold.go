@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+threshold - A nagios threshold range, a value outside of start..end (or inside if inverted) raises an alert
+*/
+type threshold struct {
+	raw      string
+	start    float64
+	end      float64
+	inverted bool
+}
+
+/*
+parseThreshold - Parses the nagios range format: "10", "10:", "~:10", "10:20" and "@10:20". An empty string never
+raises an alert.
+*/
+func parseThreshold(raw string) (*threshold, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	t := &threshold{raw: raw, end: math.Inf(1)}
+	s := raw
+	if strings.HasPrefix(s, "@") {
+		t.inverted = true
+		s = s[1:]
+	}
+	start, end := "0", s
+	if i := strings.Index(s, ":"); i >= 0 {
+		start, end = s[:i], s[i+1:]
+	}
+
+	var err error
+	if start == "~" {
+		t.start = math.Inf(-1)
+	} else if t.start, err = strconv.ParseFloat(start, 64); err != nil {
+		return nil, errors.Wrap(err, "invalid threshold '"+raw+"'")
+	}
+	if end != "" {
+		if t.end, err = strconv.ParseFloat(end, 64); err != nil {
+			return nil, errors.Wrap(err, "invalid threshold '"+raw+"'")
+		}
+	}
+	if t.start > t.end {
+		return nil, errors.New("invalid threshold '" + raw + "': start is greater than end")
+	}
+	return t, nil
+}
+
+/*
+alerts - Checks whether the value raises an alert
+*/
+func (t *threshold) alerts(value float64) bool {
+	if t == nil {
+		return false
+	}
+	outside := value < t.start || value > t.end
+	if t.inverted {
+		return !outside
+	}
+	return outside
+}
+
+func (t *threshold) String() string {
+	if t == nil {
+		return ""
+	}
+	return t.raw
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+TestParseThreshold covers:
+	- parseThreshold
+	- threshold.alerts
+*/
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		raw     string
+		alerts  []float64
+		passes  []float64
+		invalid bool
+	}{
+		{raw: "10", alerts: []float64{-1, 10.5, 11}, passes: []float64{0, 5, 10}},
+		{raw: "10:", alerts: []float64{-5, 9.9}, passes: []float64{10, 1000}},
+		{raw: "~:10", alerts: []float64{10.1, 20}, passes: []float64{-1000, 0, 10}},
+		{raw: "10:20", alerts: []float64{9, 21}, passes: []float64{10, 15, 20}},
+		{raw: "@10:20", alerts: []float64{10, 15, 20}, passes: []float64{9, 21}},
+		{raw: "", passes: []float64{-1000, 0, 1000}},
+		{raw: "abc", invalid: true},
+		{raw: "10:abc", invalid: true},
+		{raw: "20:10", invalid: true},
+	}
+	for _, test := range tests {
+		threshold, err := parseThreshold(test.raw)
+		if test.invalid {
+			assert.Error(t, err, "Invalid threshold '"+test.raw+"' was accepted")
+			continue
+		}
+		if !assert.NoError(t, err, "Error during parseThreshold operation for '"+test.raw+"'") {
+			continue
+		}
+		assert.Equal(t, test.raw, threshold.String(), "Threshold string does not match expected value")
+		for _, value := range test.alerts {
+			assert.True(t, threshold.alerts(value), "Threshold '%s' does not alert for %v", test.raw, value)
+		}
+		for _, value := range test.passes {
+			assert.False(t, threshold.alerts(value), "Threshold '%s' alerts for %v", test.raw, value)
+		}
+	}
+}
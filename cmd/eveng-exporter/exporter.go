@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+)
+
+/*
+exporter - Periodically collects metrics of an eve-ng server and serves the last collected metrics
+*/
+type exporter struct {
+	client      *evengclient.EveNgClient
+	root        evengclient.FolderPath
+	labs        bool
+	concurrency int
+
+	loggedIn     bool
+	scrapeErrors map[string]int
+
+	mutex   sync.Mutex
+	metrics []byte
+}
+
+func newExporter(client *evengclient.EveNgClient, root evengclient.FolderPath, labs bool, concurrency int) *exporter {
+	return &exporter{
+		client:       client,
+		root:         root,
+		labs:         labs,
+		concurrency:  concurrency,
+		scrapeErrors: map[string]int{"login": 0, "status": 0, "users": 0, "labs": 0},
+	}
+}
+
+/*
+run - Collects metrics immediately and then once per interval until ctx is canceled
+*/
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.collect(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mutex.Lock()
+	metrics := e.metrics
+	e.mutex.Unlock()
+	if metrics == nil {
+		http.Error(w, "no metrics collected yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(metrics)
+}
+
+/*
+collect - Collects all metrics and replaces the served metrics
+*/
+func (e *exporter) collect(ctx context.Context) {
+	start := time.Now()
+	w := &metricWriter{}
+	up := true
+
+	if !e.loggedIn {
+		err := e.client.Login()
+		if err != nil {
+			e.fail("login", err)
+			up = false
+		}
+		e.loggedIn = err == nil
+	}
+	if e.loggedIn {
+		if err := e.collectStatus(w); err != nil {
+			e.fail("status", err)
+			// the session may have expired, log in again during the next collection
+			e.loggedIn = false
+			up = false
+		}
+	}
+	if e.loggedIn {
+		if err := e.collectUsers(w); err != nil {
+			e.fail("users", err)
+			up = false
+		}
+	}
+	if e.loggedIn && e.labs {
+		if err := e.collectLabs(ctx, w); err != nil {
+			e.fail("labs", err)
+			up = false
+		}
+	}
+
+	w.gauge("eveng_up", "Whether the last collection of all metrics succeeded.", boolValue(up))
+	w.family("eveng_scrape_errors_total", "counter", "Number of failed collections per operation.")
+	operations := make([]string, 0, len(e.scrapeErrors))
+	for operation := range e.scrapeErrors {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	for _, operation := range operations {
+		w.sample("eveng_scrape_errors_total", float64(e.scrapeErrors[operation]), "operation", operation)
+	}
+	w.gauge("eveng_collection_duration_seconds", "Duration of the last collection.", time.Since(start).Seconds())
+	w.gauge("eveng_last_collection_timestamp_seconds", "Unix time of the last collection.", float64(time.Now().Unix()))
+
+	e.mutex.Lock()
+	e.metrics = w.Bytes()
+	e.mutex.Unlock()
+}
+
+func (e *exporter) collectStatus(w *metricWriter) error {
+	status, err := e.client.GetSystemStatus()
+	if err != nil {
+		return err
+	}
+	w.family("eveng_info", "gauge", "Version information of the eve-ng server.")
+	w.sample("eveng_info", 1, "version", status.Version, "qemu_version", status.Qemuversion)
+	w.gauge("eveng_cpu_usage_percent", "Cpu usage of the server.", float64(status.CPU))
+	w.gauge("eveng_memory_usage_percent", "Memory usage of the server without page cache.", float64(status.Mem))
+	w.gauge("eveng_memory_cached_percent", "Memory used by the page cache of the server.", float64(status.Cached))
+	w.gauge("eveng_swap_usage_percent", "Swap usage of the server.", float64(status.Swap))
+	w.gauge("eveng_disk_usage_percent", "Disk usage of the server.", float64(status.Disk))
+	w.counts("eveng_running_instances", "Number of running node instances per type.", "type", map[string]int{
+		"qemu":     status.Qemu,
+		"iol":      status.Iol,
+		"dynamips": status.Dynamips,
+	})
+	return nil
+}
+
+func (e *exporter) collectUsers(w *metricWriter) error {
+	users, err := e.client.GetUsers()
+	if err != nil {
+		return err
+	}
+	usernames := make([]string, 0, len(users))
+	online := 0
+	for username, user := range users {
+		usernames = append(usernames, username)
+		if user.Online != 0 {
+			online++
+		}
+	}
+	sort.Strings(usernames)
+
+	w.gauge("eveng_users", "Number of users.", float64(len(users)))
+	w.gauge("eveng_users_online", "Number of online users.", float64(online))
+	w.family("eveng_user_online", "gauge", "Whether a user is online.")
+	for _, username := range usernames {
+		w.sample("eveng_user_online", boolValue(users[username].Online != 0), "username", username)
+	}
+	w.family("eveng_user_disk_usage_gigabytes", "gauge", "Disk usage of the labs of a user as reported by eve-ng.")
+	for _, username := range usernames {
+		w.sample("eveng_user_disk_usage_gigabytes", users[username].DiskUsage, "username", username)
+	}
+	return nil
+}
+
+func (e *exporter) collectLabs(ctx context.Context, w *metricWriter) error {
+	var labs []evengclient.LabPath
+	err := e.client.WalkFoldersWithOptions(ctx, e.root, evengclient.WalkOptions{Concurrency: e.concurrency}, func(entry evengclient.WalkEntry) error {
+		if entry.IsLab {
+			labs = append(labs, evengclient.LabPath(entry.Path))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(labs, func(a, b int) bool {
+		return labs[a] < labs[b]
+	})
+
+	nodes := make([]evengclient.Nodes, len(labs))
+	errs := make([]error, len(labs))
+	var wait sync.WaitGroup
+	client := e.client.WithContext(ctx)
+	semaphore := make(chan struct{}, e.concurrency)
+	for i := range labs {
+		wait.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+			nodes[i], errs[i] = client.GetNodes(labs[i])
+		}(i)
+	}
+	wait.Wait()
+	return writeLabMetrics(w, labs, nodes, errs)
+}
+
+/*
+writeLabMetrics - Writes the number of labs and the node statuses per lab. Labs removed while collecting are not
+counted, other labs whose nodes could not be retrieved are left out and the first of their errors is returned.
+*/
+func writeLabMetrics(w *metricWriter, labs []evengclient.LabPath, nodes []evengclient.Nodes, errs []error) error {
+	removed := 0
+	for _, err := range errs {
+		if isNotFound(err) {
+			removed++
+		}
+	}
+	w.gauge("eveng_labs", "Number of labs.", float64(len(labs)-removed))
+	w.family("eveng_lab_nodes", "gauge", "Number of nodes per lab and status.")
+	var firstErr error
+	for i, labPath := range labs {
+		if errs[i] != nil {
+			if firstErr == nil && !isNotFound(errs[i]) {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		statuses := map[string]int{"stopped": 0, "running": 0}
+		for _, node := range nodes[i] {
+			statuses[nodeStatus(node.Status)]++
+		}
+		names := make([]string, 0, len(statuses))
+		for status := range statuses {
+			names = append(names, status)
+		}
+		sort.Strings(names)
+		for _, status := range names {
+			w.sample("eveng_lab_nodes", float64(statuses[status]), "lab", labPath.String(), "status", status)
+		}
+	}
+	return firstErr
+}
+
+/*
+fail - Counts and logs a failed collection
+*/
+func (e *exporter) fail(operation string, err error) {
+	e.scrapeErrors[operation]++
+	log.Printf("error while collecting %s metrics: %v", operation, err)
+}
+
+/*
+isNotFound - Checks whether err is a 404 response of the api
+*/
+func isNotFound(err error) bool {
+	httpError, ok := errors.Cause(err).(evengclient.HTTPError)
+	return ok && httpError.StatusCode == 404
+}
+
+func nodeStatus(status int) string {
+	switch status {
+	case evengclient.NodeStatusStopped:
+		return "stopped"
+	case evengclient.NodeStatusRunning:
+		return "running"
+	}
+	return strconv.Itoa(status)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
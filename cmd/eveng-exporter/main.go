@@ -0,0 +1,63 @@
+/*
+Command eveng-exporter exposes metrics of an eve-ng server in the prometheus text format.
+
+It periodically collects the system status, the users and the nodes of all labs below a folder and serves the
+last collected metrics on /metrics. Url, username and password default to the EVE_NG_API_BASEURL,
+EVE_NG_API_USERNAME and EVE_NG_API_PASSWORD environment variables, so the password does not have to be passed as flag.
+
+	eveng-exporter -url https://eve-ng.example.com -username admin -listen :9496
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+)
+
+func main() {
+	listen := flag.String("listen", ":9496", "address to serve metrics on")
+	baseURL := flag.String("url", os.Getenv("EVE_NG_API_BASEURL"), "base url of the eve-ng server")
+	username := flag.String("username", os.Getenv("EVE_NG_API_USERNAME"), "eve-ng username")
+	// the password is read from the environment after parsing, flag would print it as default value in the usage
+	password := flag.String("password", "", "eve-ng password (default $EVE_NG_API_PASSWORD)")
+	interval := flag.Duration("interval", 30*time.Second, "interval between two collections")
+	root := flag.String("root", "/", "folder whose labs are collected")
+	labs := flag.Bool("labs", true, "collect the nodes of all labs")
+	concurrency := flag.Int("concurrency", 4, "number of parallel requests while collecting labs")
+	flag.Parse()
+	if *password == "" {
+		*password = os.Getenv("EVE_NG_API_PASSWORD")
+	}
+
+	if *concurrency <= 0 {
+		log.Fatal("concurrency must be positive")
+	}
+	client, err := evengclient.NewEveNgClient(*baseURL)
+	if err != nil {
+		log.Fatalf("error while creating client: %v", err)
+	}
+	err = client.SetUsernameAndPassword(*username, *password)
+	if err != nil {
+		log.Fatalf("error while setting username and password: %v", err)
+	}
+
+	e := newExporter(client, evengclient.FolderPath(*root), *labs, *concurrency)
+	go e.run(context.Background(), *interval)
+
+	http.Handle("/metrics", e)
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<html><head><title>eve-ng exporter</title></head><body><h1>eve-ng exporter</h1><p><a href="/metrics">Metrics</a></p></body></html>`))
+	})
+	log.Printf("serving metrics on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+metricWriter - Writes metrics in the prometheus text exposition format
+*/
+type metricWriter struct {
+	buf      bytes.Buffer
+	families map[string]bool
+}
+
+/*
+family - Writes the HELP and TYPE lines of a metric family, samples of a family have to follow directly
+*/
+func (w *metricWriter) family(name string, metricType string, help string) {
+	if w.families == nil {
+		w.families = make(map[string]bool)
+	}
+	if w.families[name] {
+		return
+	}
+	w.families[name] = true
+	w.buf.WriteString("# HELP " + name + " " + escapeHelp(help) + "\n")
+	w.buf.WriteString("# TYPE " + name + " " + metricType + "\n")
+}
+
+/*
+sample - Writes a sample, labels are given as alternating names and values
+*/
+func (w *metricWriter) sample(name string, value float64, labels ...string) {
+	w.buf.WriteString(name)
+	if len(labels) > 0 {
+		w.buf.WriteString("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.buf.WriteString(",")
+			}
+			w.buf.WriteString(labels[i] + `="` + escapeLabelValue(labels[i+1]) + `"`)
+		}
+		w.buf.WriteString("}")
+	}
+	w.buf.WriteString(" " + formatValue(value) + "\n")
+}
+
+/*
+gauge - Writes a metric family with a single sample
+*/
+func (w *metricWriter) gauge(name string, help string, value float64) {
+	w.family(name, "gauge", help)
+	w.sample(name, value)
+}
+
+/*
+counts - Writes a metric family with one sample per key of counts, sorted by key
+*/
+func (w *metricWriter) counts(name string, help string, label string, counts map[string]int) {
+	w.family(name, "gauge", help)
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		w.sample(name, float64(counts[key]), label, key)
+	}
+}
+
+func (w *metricWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
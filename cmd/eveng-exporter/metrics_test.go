@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+TestMetricWriter covers:
+	- metricWriter.family
+	- metricWriter.sample
+	- metricWriter.gauge
+	- metricWriter.counts
+*/
+func TestMetricWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(w *metricWriter)
+		expected string
+	}{
+		{
+			name: "gauge",
+			write: func(w *metricWriter) {
+				w.gauge("eveng_users", "Number of users.", 3)
+			},
+			expected: "# HELP eveng_users Number of users.\n# TYPE eveng_users gauge\neveng_users 3\n",
+		},
+		{
+			name: "family is written once",
+			write: func(w *metricWriter) {
+				w.family("eveng_up", "gauge", "Up.")
+				w.sample("eveng_up", 1)
+				w.family("eveng_up", "gauge", "Up.")
+				w.sample("eveng_up", 0.5)
+			},
+			expected: "# HELP eveng_up Up.\n# TYPE eveng_up gauge\neveng_up 1\neveng_up 0.5\n",
+		},
+		{
+			name: "help escaping",
+			write: func(w *metricWriter) {
+				w.family("eveng_up", "gauge", "Line\nwith \\ backslash")
+			},
+			expected: "# HELP eveng_up Line\\nwith \\\\ backslash\n# TYPE eveng_up gauge\n",
+		},
+		{
+			name: "label escaping",
+			write: func(w *metricWriter) {
+				w.sample("eveng_lab_nodes", 2, "lab", "/Folder \"A\"/B\\C\nD.unl", "status", "running")
+			},
+			expected: "eveng_lab_nodes{lab=\"/Folder \\\"A\\\"/B\\\\C\\nD.unl\",status=\"running\"} 2\n",
+		},
+		{
+			name: "counts sorted by key",
+			write: func(w *metricWriter) {
+				w.counts("eveng_scrape_errors", "Failed collections.", "operation", map[string]int{"users": 1, "labs": 0})
+			},
+			expected: "# HELP eveng_scrape_errors Failed collections.\n# TYPE eveng_scrape_errors gauge\n" +
+				"eveng_scrape_errors{operation=\"labs\"} 0\neveng_scrape_errors{operation=\"users\"} 1\n",
+		},
+	}
+	for _, test := range tests {
+		var w metricWriter
+		test.write(&w)
+		assert.Equal(t, test.expected, string(w.Bytes()), "Output of "+test.name+" does not match expected value")
+	}
+}
+
+/*
+TestWriteLabMetrics covers:
+	- writeLabMetrics
+	- isNotFound
+*/
+func TestWriteLabMetrics(t *testing.T) {
+	labs := []evengclient.LabPath{"/A.unl", "/Failed.unl", "/Folder/B.unl", "/Removed.unl"}
+	nodes := []evengclient.Nodes{
+		{
+			"1": evengclient.NodeWithID{ID: 1, Node: evengclient.Node{Status: evengclient.NodeStatusRunning}},
+			"2": evengclient.NodeWithID{ID: 2, Node: evengclient.Node{Status: evengclient.NodeStatusStopped}},
+			"3": evengclient.NodeWithID{ID: 3, Node: evengclient.Node{Status: evengclient.NodeStatusRunning}},
+		},
+		nil,
+		{},
+		nil,
+	}
+	failed := errors.New("internal server error")
+	removed := errors.Wrap(evengclient.HTTPError{StatusCode: 404, Status: "404 Not Found"}, "error while retrieving nodes")
+	errs := []error{nil, failed, nil, removed}
+
+	var w metricWriter
+	err := writeLabMetrics(&w, labs, nodes, errs)
+	assert.Equal(t, failed, err, "Error of a lab was not returned")
+	assert.Equal(t, "# HELP eveng_labs Number of labs.\n# TYPE eveng_labs gauge\neveng_labs 3\n"+
+		"# HELP eveng_lab_nodes Number of nodes per lab and status.\n# TYPE eveng_lab_nodes gauge\n"+
+		"eveng_lab_nodes{lab=\"/A.unl\",status=\"running\"} 2\n"+
+		"eveng_lab_nodes{lab=\"/A.unl\",status=\"stopped\"} 1\n"+
+		"eveng_lab_nodes{lab=\"/Folder/B.unl\",status=\"running\"} 0\n"+
+		"eveng_lab_nodes{lab=\"/Folder/B.unl\",status=\"stopped\"} 0\n", string(w.Bytes()), "Lab metrics do not match expected value")
+
+	w = metricWriter{}
+	err = writeLabMetrics(&w, labs[2:], nodes[2:], errs[2:])
+	assert.NoError(t, err, "Error of a removed lab was returned")
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newFolderCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "folder",
+		Short: "Manage folders",
+	}
+	cmd.AddCommand(
+		newFolderListCommand(a),
+		newFolderCreateCommand(a),
+		newFolderRemoveCommand(a),
+	)
+	return cmd
+}
+
+func newFolderListCommand(a *app) *cobra.Command {
+	var recursive bool
+	var depth int
+	cmd := &cobra.Command{
+		Use:     "ls [folder]",
+		Aliases: []string{"list"},
+		Short:   "List the subfolders of a folder",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			folder, err := parseFolderPath(args)
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if !recursive {
+				depth = 1
+			}
+			tree, err := c.GetFolderTree(context.Background(), folder, depth)
+			if err != nil {
+				return errors.Wrap(err, "error while listing folders")
+			}
+			t := &table{headers: []string{"PATH", "LABS"}, data: tree}
+			addFolderRows(t, tree, recursive)
+			return a.print(t)
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "list all folders below the folder as tree")
+	cmd.Flags().IntVar(&depth, "depth", 0, "maximum depth of a recursive listing, 0 for unlimited")
+	return cmd
+}
+
+/*
+addFolderRows - Adds a row per subfolder of the tree, indented by depth for recursive listings
+*/
+func addFolderRows(t *table, tree *evengclient.FolderTree, recursive bool) {
+	var add func(folder *evengclient.FolderTree, depth int)
+	add = func(folder *evengclient.FolderTree, depth int) {
+		for _, subfolder := range folder.Folders {
+			name := subfolder.Path.String()
+			if recursive {
+				name = strings.Repeat("  ", depth) + subfolder.Name + "/"
+			}
+			t.add(name, strconv.Itoa(len(subfolder.Labs)))
+			if recursive {
+				add(subfolder, depth+1)
+			}
+		}
+	}
+	add(tree, 0)
+}
+
+func newFolderCreateCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "mkdir <folder>...",
+		Aliases: []string{"create"},
+		Short:   "Create folders including missing parent folders",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for _, arg := range args {
+				folder, err := evengclient.ParseFolderPath(arg)
+				if err != nil {
+					return err
+				}
+				err = c.EnsureFolder(folder)
+				if err != nil {
+					return errors.Wrap(err, "error while creating folder "+folder.String())
+				}
+				a.printf("created folder %s", folder)
+			}
+			return nil
+		},
+	}
+}
+
+func newFolderRemoveCommand(a *app) *cobra.Command {
+	var recursive, dryRun bool
+	cmd := &cobra.Command{
+		Use:     "rm <folder>",
+		Aliases: []string{"remove"},
+		Short:   "Remove an empty folder, or a folder with all its contents with --recursive",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			folder, err := evengclient.ParseFolderPath(args[0])
+			if err != nil {
+				return err
+			}
+			if dryRun && !recursive {
+				return errors.New("--dry-run requires --recursive")
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if !recursive {
+				err = c.RemoveFolder(folder)
+				if err != nil {
+					return errors.Wrap(err, "error while removing folder")
+				}
+				a.printf("removed folder %s", folder)
+				return nil
+			}
+			report, err := c.RemoveFolderRecursive(folder, evengclient.RemoveFolderOptions{DryRun: dryRun})
+			stopped, removed := "stopped nodes of", "removed"
+			if dryRun {
+				stopped, removed = "would stop nodes of", "would remove"
+			}
+			t := &table{headers: []string{"ACTION", "PATH"}, data: report}
+			for _, labPath := range report.StoppedLabs {
+				t.add(stopped, labPath.String())
+			}
+			for _, labPath := range report.Labs {
+				t.add(removed, labPath.String())
+			}
+			for _, folderPath := range report.Folders {
+				t.add(removed, folderPath.String()+"/")
+			}
+			if printErr := a.print(t); printErr != nil && err == nil {
+				err = printErr
+			}
+			return errors.Wrap(err, "error while removing folder")
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "remove all labs and subfolders, stopping running nodes")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only list what would be removed")
+	return cmd
+}
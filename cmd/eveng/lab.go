@@ -0,0 +1,254 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newLabCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lab",
+		Short: "Manage labs",
+	}
+	cmd.AddCommand(
+		newLabListCommand(a),
+		newLabCreateCommand(a),
+		newLabRemoveCommand(a),
+		newLabMoveCommand(a),
+		newLabCloneCommand(a),
+		newLabExportCommand(a),
+	)
+	return cmd
+}
+
+func newLabListCommand(a *app) *cobra.Command {
+	var recursive bool
+	var pattern string
+	cmd := &cobra.Command{
+		Use:     "ls [folder]",
+		Aliases: []string{"list"},
+		Short:   "List the labs of a folder",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			folder, err := parseFolderPath(args)
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			var labs evengclient.LabFiles
+			if recursive || pattern != "*" {
+				labs, err = c.FindLabs(folder, pattern)
+			} else {
+				labs, err = c.GetLabFiles(folder)
+			}
+			if err != nil {
+				return errors.Wrap(err, "error while listing labs")
+			}
+			t := &table{headers: []string{"PATH"}, data: labs}
+			for _, lab := range labs {
+				t.add(lab.LabPath().String())
+			}
+			return a.print(t)
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "list the labs of all subfolders")
+	cmd.Flags().StringVar(&pattern, "name", "*", "only list labs matching this shell pattern, implies --recursive")
+	return cmd
+}
+
+func newLabCreateCommand(a *app) *cobra.Command {
+	var version, author, description, body string
+	cmd := &cobra.Command{
+		Use:   "create <lab>",
+		Short: "Create an empty lab",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			err = c.AddLab(labPath.Folder(), labPath.Name(), version, author, description, body)
+			if err != nil {
+				return errors.Wrap(err, "error while creating lab")
+			}
+			a.printf("created lab %s", labPath)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&version, "version", "1", "lab version")
+	cmd.Flags().StringVar(&author, "author", "", "lab author")
+	cmd.Flags().StringVar(&description, "description", "", "lab description")
+	cmd.Flags().StringVar(&body, "body", "", "lab body text")
+	return cmd
+}
+
+func newLabRemoveCommand(a *app) *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:     "rm <lab>...",
+		Aliases: []string{"remove"},
+		Short:   "Remove labs",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for _, arg := range args {
+				labPath, err := parseLabPath(arg)
+				if err != nil {
+					return err
+				}
+				if force {
+					err = c.StopNodes(labPath)
+					if err != nil {
+						return errors.Wrap(err, "error while stopping nodes of lab "+labPath.String())
+					}
+				}
+				err = c.RemoveLab(labPath)
+				if err != nil {
+					return errors.Wrap(err, "error while removing lab "+labPath.String())
+				}
+				a.printf("removed lab %s", labPath)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "stop running nodes before removing the lab")
+	return cmd
+}
+
+func newLabMoveCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "mv <lab> <folder>",
+		Aliases: []string{"move"},
+		Short:   "Move a lab to another folder",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			folder, err := parseFolderPath(args[1:])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			err = c.MoveLab(labPath, folder)
+			if err != nil {
+				return errors.Wrap(err, "error while moving lab")
+			}
+			a.printf("moved lab %s to %s", labPath, folder)
+			return nil
+		},
+	}
+}
+
+func newLabCloneCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "clone <lab> <new lab>",
+		Aliases: []string{"cp"},
+		Short:   "Copy a lab with a new id",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			newPath, err := parseLabPath(args[1])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			err = c.CloneLab(labPath, newPath)
+			if err != nil {
+				return errors.Wrap(err, "error while cloning lab")
+			}
+			a.printf("cloned lab %s to %s", labPath, newPath)
+			return nil
+		},
+	}
+}
+
+func newLabExportCommand(a *app) *cobra.Command {
+	var file string
+	var unl bool
+	cmd := &cobra.Command{
+		Use:   "export <lab>...",
+		Short: "Export labs as a zip archive or a single lab as .unl file",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if unl && len(args) > 1 {
+				return errors.New("only one lab can be exported with --unl")
+			}
+			var labPaths []evengclient.LabPath
+			for _, arg := range args {
+				labPath, err := parseLabPath(arg)
+				if err != nil {
+					return err
+				}
+				labPaths = append(labPaths, labPath)
+			}
+			if file == "" {
+				file = labPaths[0].Name() + ".zip"
+				if unl {
+					file = labPaths[0].File()
+				}
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			var data []byte
+			if unl {
+				data, err = c.DownloadLabFile(labPaths[0])
+			} else {
+				data, err = exportLabs(c, labPaths)
+			}
+			if err != nil {
+				return errors.Wrap(err, "error while exporting labs")
+			}
+			err = ioutil.WriteFile(file, data, 0644)
+			if err != nil {
+				return errors.Wrap(err, "error while writing export file")
+			}
+			a.printf("exported %s to %s", strings.Join(args, ", "), file)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "output file (default <lab>.zip or <lab>.unl)")
+	cmd.Flags().BoolVar(&unl, "unl", false, "download the native .unl file instead of a zip archive")
+	return cmd
+}
+
+/*
+exportLabs - Exports labs from the same folder as zip archive
+*/
+func exportLabs(c *evengclient.EveNgClient, labPaths []evengclient.LabPath) ([]byte, error) {
+	folder := labPaths[0].Folder()
+	var paths []string
+	for _, labPath := range labPaths {
+		if labPath.Folder() != folder {
+			return nil, errors.New("all exported labs must be in the same folder")
+		}
+		paths = append(paths, labPath.String())
+	}
+	return c.ExportLabs(folder, paths...)
+}
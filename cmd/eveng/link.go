@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newLinkCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link",
+		Short: "Connect and disconnect node interfaces",
+	}
+	cmd.AddCommand(
+		newLinkConnectCommand(a),
+		newLinkDisconnectCommand(a),
+	)
+	return cmd
+}
+
+func newLinkConnectCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "connect <lab> <node> <interface> <network|node:interface>",
+		Short: "Connect an ethernet interface to a network or a serial interface to another node",
+		Example: "  eveng link connect /Demo/Core.unl R1 e0/0 Backbone\n" +
+			"  eveng link connect /Demo/Core.unl R1 s1/0 R2:s1/0",
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			nodes, err := c.GetNodes(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving nodes")
+			}
+			node, err := findNode(nodes, args[1])
+			if err != nil {
+				return err
+			}
+			if i := strings.LastIndex(args[3], ":"); i >= 0 {
+				remote, err := findNode(nodes, args[3][:i])
+				if err != nil {
+					return err
+				}
+				err = c.ConnectNodeSerialInterfaces(labPath, node.ID, args[2], remote.ID, args[3][i+1:])
+				if err != nil {
+					return errors.Wrap(err, "error while connecting serial interfaces")
+				}
+				a.printf("connected %s %s to %s %s", node.Name, args[2], remote.Name, args[3][i+1:])
+				return nil
+			}
+			networks, err := c.GetNetworks(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving networks")
+			}
+			network, err := findNetwork(networks, args[3])
+			if err != nil {
+				return err
+			}
+			err = c.ConnectNodeInterfaceToNetworkByName(labPath, node.ID, args[2], network.ID)
+			if err != nil {
+				return errors.Wrap(err, "error while connecting interface")
+			}
+			a.printf("connected %s %s to network %s", node.Name, args[2], network.Name)
+			return nil
+		},
+	}
+}
+
+func newLinkDisconnectCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "disconnect <lab> <node> <interface>",
+		Short: "Disconnect a node interface",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			nodes, err := c.GetNodes(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving nodes")
+			}
+			node, err := findNode(nodes, args[1])
+			if err != nil {
+				return err
+			}
+			err = c.DisconnectNodeInterfaceByName(labPath, node.ID, args[2])
+			if err != nil {
+				return errors.Wrap(err, "error while disconnecting interface")
+			}
+			a.printf("disconnected %s %s", node.Name, args[2])
+			return nil
+		},
+	}
+}
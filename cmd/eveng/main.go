@@ -0,0 +1,24 @@
+/*
+Command eveng administrates eve-ng servers from the command line.
+
+Labs, nodes, networks, links, users and folders are managed with subcommands, see "eveng help". The server is
+selected with a profile from the config file, the EVE_NG_API_* environment variables or the --url, --username and
+--password flags, in increasing order of precedence.
+
+	eveng --profile lab1 lab ls --recursive
+	eveng node start /Demo/Core.unl R1 R2
+	eveng -o json status
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
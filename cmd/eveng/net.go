@@ -0,0 +1,141 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newNetCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "net",
+		Aliases: []string{"network"},
+		Short:   "Manage the networks of a lab",
+	}
+	cmd.AddCommand(
+		newNetListCommand(a),
+		newNetCreateCommand(a),
+		newNetRemoveCommand(a),
+	)
+	return cmd
+}
+
+func newNetListCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls <lab>",
+		Aliases: []string{"list"},
+		Short:   "List the networks of a lab",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			networks, err := c.GetNetworks(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving networks")
+			}
+			list := make([]evengclient.NetworkWithID, 0, len(networks))
+			for _, network := range networks {
+				list = append(list, network)
+			}
+			sort.Slice(list, func(i, j int) bool {
+				return list[i].ID < list[j].ID
+			})
+			t := &table{headers: []string{"ID", "NAME", "TYPE", "LINKS", "VISIBLE"}, data: list}
+			for _, network := range list {
+				t.add(strconv.Itoa(network.ID), network.Name, network.Type, strconv.Itoa(network.Count),
+					strconv.FormatBool(network.Visibility != 0))
+			}
+			return a.print(t)
+		},
+	}
+}
+
+func newNetCreateCommand(a *app) *cobra.Command {
+	var networkType string
+	var left, top, visibility int
+	cmd := &cobra.Command{
+		Use:   "create <lab> <name>",
+		Short: "Create a network",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			id, err := c.AddNetwork(labPath, networkType, args[1], left, top, visibility, 0)
+			if err != nil {
+				return errors.Wrap(err, "error while creating network")
+			}
+			a.printf("created network %s with id %d", args[1], id)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&networkType, "type", "bridge", "network type, e.g. bridge or nat0")
+	cmd.Flags().IntVar(&left, "left", 0, "horizontal position in percent")
+	cmd.Flags().IntVar(&top, "top", 0, "vertical position in percent")
+	cmd.Flags().IntVar(&visibility, "visibility", 1, "1 to show the network in the topology, 0 to hide it")
+	return cmd
+}
+
+func newNetRemoveCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <lab> <network>...",
+		Aliases: []string{"remove"},
+		Short:   "Remove networks by name or id",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			networks, err := c.GetNetworks(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving networks")
+			}
+			for _, arg := range args[1:] {
+				network, err := findNetwork(networks, arg)
+				if err != nil {
+					return err
+				}
+				err = c.RemoveNetwork(labPath, network.ID)
+				if err != nil {
+					return errors.Wrap(err, "error while removing network "+network.Name)
+				}
+				a.printf("removed network %s", network.Name)
+			}
+			return nil
+		},
+	}
+}
+
+/*
+findNetwork - Finds a network by id or name
+*/
+func findNetwork(networks evengclient.Networks, nameOrID string) (evengclient.NetworkWithID, error) {
+	if network, ok := networks[nameOrID]; ok {
+		return network, nil
+	}
+	for _, network := range networks {
+		if network.Name == nameOrID {
+			return network, nil
+		}
+	}
+	return evengclient.NetworkWithID{}, errors.New("network '" + nameOrID + "' not found")
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newNodeCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Manage the nodes of a lab",
+	}
+	cmd.AddCommand(
+		newNodeListCommand(a),
+		newNodeActionCommand(a, "start", "Start nodes", "started",
+			(*evengclient.EveNgClient).StartNodes, (*evengclient.EveNgClient).StartNode),
+		newNodeActionCommand(a, "stop", "Stop nodes", "stopped",
+			(*evengclient.EveNgClient).StopNodes, (*evengclient.EveNgClient).StopNode),
+		newNodeActionCommand(a, "wipe", "Wipe nodes, resetting them to their startup configuration", "wiped",
+			(*evengclient.EveNgClient).WipeNodes, (*evengclient.EveNgClient).WipeNode),
+		newNodeActionCommand(a, "export", "Export the running configuration of nodes as startup configuration", "exported",
+			(*evengclient.EveNgClient).ExportNodes, (*evengclient.EveNgClient).ExportNode),
+		newNodeEditCommand(a),
+	)
+	return cmd
+}
+
+func newNodeListCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls <lab>",
+		Aliases: []string{"list"},
+		Short:   "List the nodes of a lab",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			nodes, err := c.GetNodes(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving nodes")
+			}
+			list := sortedNodes(nodes)
+			t := &table{headers: []string{"ID", "NAME", "TEMPLATE", "IMAGE", "STATUS", "CPU", "RAM", "CONSOLE"}, data: list}
+			for _, node := range list {
+				t.add(strconv.Itoa(node.ID), node.Name, node.Template, node.Image, nodeStatus(node.Status),
+					strconv.Itoa(node.CPU), strconv.Itoa(node.RAM), node.URL)
+			}
+			return a.print(t)
+		},
+	}
+}
+
+/*
+newNodeActionCommand - Creates a command which runs an action on all nodes of a lab or on the given nodes
+*/
+func newNodeActionCommand(a *app, name, short, done string,
+	all func(*evengclient.EveNgClient, evengclient.LabPath) error,
+	single func(*evengclient.EveNgClient, evengclient.LabPath, int) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " <lab> [node]...",
+		Short: short + ", all nodes of the lab if no node names or ids are given",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				err = all(c, labPath)
+				if err != nil {
+					return errors.Wrap(err, "error while running "+name+" on all nodes")
+				}
+				a.printf("%s all nodes of %s", done, labPath)
+				return nil
+			}
+			nodes, err := c.GetNodes(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving nodes")
+			}
+			for _, arg := range args[1:] {
+				node, err := findNode(nodes, arg)
+				if err != nil {
+					return err
+				}
+				err = single(c, labPath, node.ID)
+				if err != nil {
+					return errors.Wrap(err, "error while running "+name+" on node "+node.Name)
+				}
+				a.printf("%s node %s", done, node.Name)
+			}
+			return nil
+		},
+	}
+}
+
+func newNodeEditCommand(a *app) *cobra.Command {
+	var edit evengclient.Node
+	cmd := &cobra.Command{
+		Use:   "edit <lab> <node>",
+		Short: "Edit the settings of a node",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labPath, err := parseLabPath(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			nodes, err := c.GetNodes(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving nodes")
+			}
+			found, err := findNode(nodes, args[1])
+			if err != nil {
+				return err
+			}
+			node := found.Node
+			flags := cmd.Flags()
+			for flag, apply := range map[string]func(){
+				"name":           func() { node.Name = edit.Name },
+				"image":          func() { node.Image = edit.Image },
+				"icon":           func() { node.Icon = edit.Icon },
+				"console":        func() { node.Console = edit.Console },
+				"startup-config": func() { node.Config = edit.Config },
+				"ram":            func() { node.RAM = edit.RAM },
+				"cpu":            func() { node.CPU = edit.CPU },
+				"ethernet":       func() { node.Ethernet = edit.Ethernet },
+				"delay":          func() { node.Delay = edit.Delay },
+				"left":           func() { node.Left = edit.Left },
+				"top":            func() { node.Top = edit.Top },
+			} {
+				if flags.Changed(flag) {
+					apply()
+				}
+			}
+			err = c.EditNode(labPath, found.ID, node)
+			if err != nil {
+				return errors.Wrap(err, "error while editing node")
+			}
+			a.printf("edited node %s", node.Name)
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&edit.Name, "name", "", "node name")
+	flags.StringVar(&edit.Image, "image", "", "node image")
+	flags.StringVar(&edit.Icon, "icon", "", "node icon")
+	flags.StringVar(&edit.Console, "console", "", "console type, e.g. telnet or vnc")
+	flags.StringVar(&edit.Config, "startup-config", "", "startup configuration, 0 for none or 1 for exported")
+	flags.IntVar(&edit.RAM, "ram", 0, "memory in MB")
+	flags.IntVar(&edit.CPU, "cpu", 0, "number of cpus")
+	flags.IntVar(&edit.Ethernet, "ethernet", 0, "number of ethernet interfaces")
+	flags.IntVar(&edit.Delay, "delay", 0, "start delay in seconds")
+	flags.IntVar(&edit.Left, "left", 0, "horizontal position in percent")
+	flags.IntVar(&edit.Top, "top", 0, "vertical position in percent")
+	return cmd
+}
+
+/*
+findNode - Finds a node by id or name
+*/
+func findNode(nodes evengclient.Nodes, nameOrID string) (evengclient.NodeWithID, error) {
+	if node, ok := nodes[nameOrID]; ok {
+		return node, nil
+	}
+	for _, node := range nodes {
+		if node.Name == nameOrID {
+			return node, nil
+		}
+	}
+	return evengclient.NodeWithID{}, errors.New("node '" + nameOrID + "' not found")
+}
+
+/*
+sortedNodes - Returns the nodes sorted by id
+*/
+func sortedNodes(nodes evengclient.Nodes) []evengclient.NodeWithID {
+	list := make([]evengclient.NodeWithID, 0, len(nodes))
+	for _, node := range nodes {
+		list = append(list, node)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+func nodeStatus(status int) string {
+	switch status {
+	case evengclient.NodeStatusStopped:
+		return "stopped"
+	case evengclient.NodeStatusRunning:
+		return "running"
+	}
+	return strconv.Itoa(status)
+}
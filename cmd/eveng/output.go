@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+/*
+table - Rows of a table output together with the data which is printed for json and yaml output
+*/
+type table struct {
+	headers []string
+	rows    [][]string
+	data    interface{}
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+/*
+print - Prints the table in the output format selected by the --output flag
+*/
+func (a *app) print(t *table) error {
+	switch a.output {
+	case outputJSON:
+		b, err := json.MarshalIndent(t.data, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal json")
+		}
+		fmt.Println(string(b))
+		return nil
+	case outputYAML:
+		// marshal to json first, so the field names match the json output
+		b, err := json.Marshal(t.data)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal json")
+		}
+		var data interface{}
+		err = yaml.Unmarshal(b, &data)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert json to yaml")
+		}
+		b, err = yaml.Marshal(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal yaml")
+		}
+		fmt.Print(string(b))
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+/*
+printf - Prints a message for table output, json and yaml output only contain data
+*/
+func (a *app) printf(format string, v ...interface{}) {
+	if a.output == outputTable {
+		fmt.Printf(format+"\n", v...)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"os"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+/*
+app - Global options and the lazily created client shared by all subcommands
+*/
+type app struct {
+	profilesFile string
+	profile      string
+	url          string
+	username     string
+	password     string
+	output       string
+
+	eveNgClient *evengclient.EveNgClient
+}
+
+func newRootCommand() *cobra.Command {
+	a := &app{}
+	root := &cobra.Command{
+		Use:           "eveng",
+		Short:         "Administrate eve-ng servers",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch a.output {
+			case outputTable, outputJSON, outputYAML:
+				return nil
+			}
+			return errors.New("invalid output format '" + a.output + "', valid formats are table, json and yaml")
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if a.eveNgClient != nil {
+				_ = a.eveNgClient.Logout()
+			}
+		},
+	}
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.profilesFile, "config", "", "profiles file (default "+evengclient.DefaultProfilesFile()+")")
+	flags.StringVarP(&a.profile, "profile", "p", os.Getenv(evengclient.ProfileEnv), "server profile from the profiles file")
+	flags.StringVar(&a.url, "url", "", "base url of the eve-ng server")
+	flags.StringVar(&a.username, "username", "", "eve-ng username")
+	flags.StringVar(&a.password, "password", "", "eve-ng password")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table, json or yaml")
+
+	root.AddCommand(
+		newLabCommand(a),
+		newNodeCommand(a),
+		newNetCommand(a),
+		newLinkCommand(a),
+		newUserCommand(a),
+		newFolderCommand(a),
+		newStatusCommand(a),
+		newTemplatesCommand(a),
+		newCompletionCommand(root),
+	)
+	return root
+}
+
+/*
+client - Returns the logged in client, which is created on first use
+*/
+func (a *app) client() (*evengclient.EveNgClient, error) {
+	if a.eveNgClient != nil {
+		return a.eveNgClient, nil
+	}
+	server, err := a.server()
+	if err != nil {
+		return nil, err
+	}
+	c, err := evengclient.NewEveNgClientFromProfile(server)
+	if err != nil {
+		return nil, err
+	}
+	err = c.Login()
+	if err != nil {
+		return nil, errors.Wrap(err, "login failed")
+	}
+	a.eveNgClient = c
+	return c, nil
+}
+
+/*
+server - Resolves the server to use from the profile, the environment and the flags. The credential provider of the
+profile is only asked for what the environment and the flags leave empty.
+*/
+func (a *app) server() (evengclient.Profile, error) {
+	var server evengclient.Profile
+	profiles, err := evengclient.LoadProfiles(a.profilesFile)
+	if err != nil {
+		return server, err
+	}
+	if a.profile != "" || profiles.Default != "" {
+		server, err = profiles.Profile(a.profile)
+		if err != nil {
+			return server, err
+		}
+	}
+	for _, setting := range []struct {
+		value *string
+		env   string
+		flag  string
+	}{
+		{&server.BaseURL, "EVE_NG_API_BASEURL", a.url},
+		{&server.Username, "EVE_NG_API_USERNAME", a.username},
+		{&server.Password, "EVE_NG_API_PASSWORD", a.password},
+	} {
+		if env := os.Getenv(setting.env); env != "" {
+			*setting.value = env
+		}
+		if setting.flag != "" {
+			*setting.value = setting.flag
+		}
+	}
+	if server.BaseURL == "" {
+		return server, errors.New("no eve-ng server given, use --url, EVE_NG_API_BASEURL or a profile")
+	}
+	return server, nil
+}
+
+/*
+parseLabPath - Parses a lab path argument
+*/
+func parseLabPath(arg string) (evengclient.LabPath, error) {
+	return evengclient.ParseLabPath(arg)
+}
+
+/*
+parseFolderPath - Parses an optional folder path argument, the root folder is used if it is missing
+*/
+func parseFolderPath(args []string) (evengclient.FolderPath, error) {
+	if len(args) == 0 {
+		return evengclient.RootFolder, nil
+	}
+	return evengclient.ParseFolderPath(args[0])
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+TestFlags covers:
+	- newRootCommand
+	- userOptions.addFlags
+	- newNodeEditCommand
+*/
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected map[string]string
+	}{
+		{
+			args:     []string{"user", "add", "bob", "--password", "x", "--user-password", "y"},
+			expected: map[string]string{"password": "x", "user-password": "y"},
+		},
+		{
+			args:     []string{"user", "edit", "bob", "--user-password", "y", "--password", "x"},
+			expected: map[string]string{"password": "x", "user-password": "y"},
+		},
+		{
+			args:     []string{"node", "edit", "/Lab.unl", "R1", "--config", "profiles.yaml", "--startup-config", "1"},
+			expected: map[string]string{"config": "profiles.yaml", "startup-config": "1"},
+		},
+	}
+	for _, test := range tests {
+		cmd, rest, err := newRootCommand().Find(test.args)
+		if !assert.NoError(t, err, "Error while finding command for %v", test.args) {
+			continue
+		}
+		if !assert.NoError(t, cmd.ParseFlags(rest), "Error while parsing flags %v", rest) {
+			continue
+		}
+		for flag, value := range test.expected {
+			actual, err := cmd.Flags().GetString(flag)
+			if assert.NoError(t, err, "Flag --%s does not exist", flag) {
+				assert.Equal(t, value, actual, "Value of --%s does not match expected value for %v", flag, test.args)
+			}
+		}
+	}
+
+	// local flags must not hide the global flags
+	root := newRootCommand()
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			global := root.PersistentFlags().Lookup(flag.Name)
+			assert.True(t, global == nil || global == flag, "Flag --%s of %s hides the global flag", flag.Name, cmd.CommandPath())
+		})
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strconv"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newStatusCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the system status of the server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			status, err := c.GetSystemStatus()
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving system status")
+			}
+			t := &table{headers: []string{"METRIC", "VALUE"}, data: status}
+			t.add("version", status.Version)
+			t.add("qemu version", status.Qemuversion)
+			t.add("cpu", strconv.Itoa(status.CPU)+"%")
+			t.add("memory", strconv.Itoa(status.Mem)+"%")
+			t.add("cached", strconv.Itoa(status.Cached)+"%")
+			t.add("swap", strconv.Itoa(status.Swap)+"%")
+			t.add("disk", strconv.Itoa(status.Disk)+"%")
+			t.add("qemu nodes", strconv.Itoa(status.Qemu))
+			t.add("iol nodes", strconv.Itoa(status.Iol))
+			t.add("dynamips nodes", strconv.Itoa(status.Dynamips))
+			return a.print(t)
+		},
+	}
+}
+
+func newTemplatesCommand(a *app) *cobra.Command {
+	var query evengclient.TemplateQuery
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "List the node templates of the server with their installed images",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			templates, err := c.NewTemplateCatalog(0).Search(query)
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving templates")
+			}
+			t := &table{headers: []string{"TEMPLATE", "TYPE", "DESCRIPTION", "NEWEST IMAGE", "IMAGES"}, data: templates}
+			for _, template := range templates {
+				newest, _ := template.Images.Newest()
+				t.add(template.Name, template.Type, template.Description, newest, strconv.Itoa(len(template.Images)))
+			}
+			return a.print(t)
+		},
+	}
+	cmd.Flags().StringVar(&query.Vendor, "vendor", "", "only list templates of this vendor")
+	cmd.Flags().StringVar(&query.Description, "description", "", "only list templates whose description contains this text")
+	cmd.Flags().StringVar(&query.Type, "type", "", "only list templates of this type, e.g. qemu, iol or dynamips")
+	cmd.Flags().BoolVar(&query.WithImages, "installed", false, "only list templates with installed images")
+	return cmd
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	evengclient "github.com/inexio/eve-ng-restapi-go-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newUserCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users",
+	}
+	cmd.AddCommand(
+		newUserListCommand(a),
+		newUserAddCommand(a),
+		newUserRemoveCommand(a),
+		newUserEditCommand(a),
+	)
+	return cmd
+}
+
+func newUserListCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List users",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			users, err := c.GetUsers()
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving users")
+			}
+			list := make([]evengclient.User, 0, len(users))
+			for _, user := range users {
+				list = append(list, user)
+			}
+			sort.Slice(list, func(i, j int) bool {
+				return list[i].Username < list[j].Username
+			})
+			t := &table{headers: []string{"USERNAME", "NAME", "EMAIL", "ROLE", "POD", "ONLINE", "LAB"}, data: list}
+			for _, user := range list {
+				t.add(user.Username, user.Name, user.Email, user.Role, user.Pod, strconv.FormatBool(user.Online != 0), user.Lab)
+			}
+			return a.print(t)
+		},
+	}
+}
+
+/*
+userOptions - Settings of a user which can be given as flags
+*/
+type userOptions struct {
+	name        string
+	email       string
+	password    string
+	role        string
+	expiration  string
+	pod         int
+	pexpiration string
+}
+
+func (o *userOptions) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.name, "name", "", "full name")
+	flags.StringVar(&o.email, "email", "", "email address")
+	// --password is the global eve-ng login password
+	flags.StringVar(&o.password, "user-password", "", "password of the user")
+	flags.StringVar(&o.role, "role", "user", "role, e.g. user, editor or admin")
+	flags.StringVar(&o.expiration, "expiration", "-1", "account expiration as unix timestamp, -1 for never")
+	flags.IntVar(&o.pod, "pod", 0, "pod number")
+	flags.StringVar(&o.pexpiration, "pod-expiration", "-1", "pod expiration as unix timestamp, -1 for never")
+}
+
+func newUserAddCommand(a *app) *cobra.Command {
+	var options userOptions
+	var cpu, ram int
+	cmd := &cobra.Command{
+		Use:   "add <username>",
+		Short: "Add a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			err = c.AddUser(args[0], options.name, options.email, options.password, options.role, options.expiration, "-1",
+				"internal", options.pod, options.pexpiration, cpu, ram)
+			if err != nil {
+				return errors.Wrap(err, "error while adding user")
+			}
+			a.printf("added user %s", args[0])
+			return nil
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().IntVar(&cpu, "cpu", -1, "cpu limit, -1 for unlimited")
+	cmd.Flags().IntVar(&ram, "ram", -1, "memory limit, -1 for unlimited")
+	return cmd
+}
+
+func newUserRemoveCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <username>...",
+		Aliases: []string{"remove"},
+		Short:   "Remove users",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for _, username := range args {
+				err = c.RemoveUser(username)
+				if err != nil {
+					return errors.Wrap(err, "error while removing user "+username)
+				}
+				a.printf("removed user %s", username)
+			}
+			return nil
+		},
+	}
+}
+
+func newUserEditCommand(a *app) *cobra.Command {
+	var options userOptions
+	cmd := &cobra.Command{
+		Use:   "edit <username>",
+		Short: "Edit a user, settings which are not given are kept",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			user, err := c.GetUser(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error while retrieving user")
+			}
+			current := userOptions{
+				name:        user.Name,
+				email:       user.Email,
+				role:        user.Role,
+				expiration:  user.Expiration,
+				pexpiration: user.Pexpiration,
+			}
+			current.pod, _ = strconv.Atoi(user.Pod)
+			flags := cmd.Flags()
+			for flag, apply := range map[string]func(){
+				"name":           func() { current.name = options.name },
+				"email":          func() { current.email = options.email },
+				"user-password":  func() { current.password = options.password },
+				"role":           func() { current.role = options.role },
+				"expiration":     func() { current.expiration = options.expiration },
+				"pod":            func() { current.pod = options.pod },
+				"pod-expiration": func() { current.pexpiration = options.pexpiration },
+			} {
+				if flags.Changed(flag) {
+					apply()
+				}
+			}
+			err = c.EditUser(args[0], current.name, current.email, current.password, current.role, current.expiration,
+				current.pod, current.pexpiration)
+			if err != nil {
+				return errors.Wrap(err, "error while editing user")
+			}
+			a.printf("edited user %s", args[0])
+			return nil
+		},
+	}
+	options.addFlags(cmd)
+	return cmd
+}
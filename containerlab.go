@@ -0,0 +1,507 @@
+package evengclient
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+ContainerlabTopology contains a containerlab topology as stored in a .clab.yml file
+*/
+type ContainerlabTopology struct {
+	Name     string                   `yaml:"name"`
+	Topology ContainerlabTopologySpec `yaml:"topology"`
+
+	// BaseDir is the directory of the topology file, relative startup-config paths are resolved against it
+	BaseDir string `yaml:"-"`
+}
+
+/*
+ContainerlabTopologySpec contains the nodes and links of a containerlab topology
+*/
+type ContainerlabTopologySpec struct {
+	Defaults *ContainerlabNode           `yaml:"defaults,omitempty"`
+	Kinds    map[string]ContainerlabNode `yaml:"kinds,omitempty"`
+	Nodes    map[string]ContainerlabNode `yaml:"nodes"`
+	Links    []ContainerlabLink          `yaml:"links,omitempty"`
+}
+
+/*
+ContainerlabNode contains the containerlab settings of a node
+*/
+type ContainerlabNode struct {
+	Kind          string            `yaml:"kind,omitempty"`
+	Image         string            `yaml:"image,omitempty"`
+	StartupConfig string            `yaml:"startup-config,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty"`
+}
+
+/*
+ContainerlabLink is a point to point link between two containerlab node interfaces, e.g. "r1:eth1"
+*/
+type ContainerlabLink struct {
+	Endpoints []string `yaml:"endpoints"`
+}
+
+/*
+KindMapping maps a containerlab kind or a gns3 template to an eve-ng node template.
+
+The eve-ng interface index of a containerlab interface "ethN" is N - 1 + InterfaceOffset, as eth0 is the management
+interface of containerlab nodes. The index of a gns3 adapter N is N + InterfaceOffset.
+*/
+type KindMapping struct {
+	Template        string `yaml:"template" json:"template"`
+	NodeType        string `yaml:"type" json:"type"`
+	Image           string `yaml:"image" json:"image"`
+	Icon            string `yaml:"icon" json:"icon"`
+	RAM             int    `yaml:"ram" json:"ram"`
+	CPU             int    `yaml:"cpu" json:"cpu"`
+	Ethernet        int    `yaml:"ethernet" json:"ethernet"`
+	Console         string `yaml:"console" json:"console"`
+	InterfaceOffset int    `yaml:"interface_offset" json:"interface_offset"`
+	ContainerImage  string `yaml:"container_image" json:"container_image"`
+}
+
+/*
+KindMappings maps containerlab kinds or gns3 templates to eve-ng node templates
+*/
+type KindMappings map[string]KindMapping
+
+/*
+ContainerlabReport lists what could not be converted between containerlab and eve-ng
+*/
+type ContainerlabReport struct {
+	LabPath       LabPath
+	NodeIDs       map[string]int
+	NetworkIDs    map[string]int
+	UnmappedNodes []string
+	SkippedLinks  []string
+	Warnings      []string
+}
+
+const (
+	clabPositionXLabel = "graph-posX"
+	clabPositionYLabel = "graph-posY"
+	clabBridgeKind     = "bridge"
+)
+
+var (
+	clabInterfaceNumber = regexp.MustCompile(`(\d+)$`)
+	clabEthInterface    = regexp.MustCompile(`^eth(\d+)$`)
+)
+
+/*
+DefaultKindMappings returns mappings for common containerlab kinds. Images have to be adjusted to the images
+installed on the eve-ng server.
+*/
+func DefaultKindMappings() KindMappings {
+	return KindMappings{
+		"ceos":           {Template: "veos", NodeType: "qemu", Icon: "AristaSW.png", RAM: 2048, CPU: 1, Ethernet: 8, Console: "telnet", ContainerImage: "ceos:latest"},
+		"arista_veos":    {Template: "veos", NodeType: "qemu", Icon: "AristaSW.png", RAM: 2048, CPU: 1, Ethernet: 8, Console: "telnet", ContainerImage: "vrnetlab/vr-veos:latest"},
+		"cisco_csr1000v": {Template: "csr1000vng", NodeType: "qemu", Icon: "CSRv1000.png", RAM: 4096, CPU: 1, Ethernet: 4, Console: "telnet", ContainerImage: "vrnetlab/vr-csr:latest"},
+		"juniper_vsrx":   {Template: "vsrxng", NodeType: "qemu", Icon: "Firewall.png", RAM: 4096, CPU: 2, Ethernet: 4, Console: "telnet", ContainerImage: "vrnetlab/vr-vsrx:latest"},
+		"linux":          {Template: "linux", NodeType: "qemu", Icon: "Server.png", RAM: 1024, CPU: 1, Ethernet: 2, Console: "vnc", ContainerImage: "alpine:latest"},
+	}
+}
+
+/*
+ReadContainerlabTopologyFile reads and parses a .clab.yml file
+*/
+func ReadContainerlabTopologyFile(filename string) (ContainerlabTopology, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return ContainerlabTopology{}, errors.Wrap(err, "error while reading file")
+	}
+	topology, err := ParseContainerlabTopology(b)
+	if err != nil {
+		return ContainerlabTopology{}, err
+	}
+	topology.BaseDir = filepath.Dir(filename)
+	return topology, nil
+}
+
+/*
+ParseContainerlabTopology parses the content of a .clab.yml file
+*/
+func ParseContainerlabTopology(data []byte) (ContainerlabTopology, error) {
+	var topology ContainerlabTopology
+	err := yaml.Unmarshal(data, &topology)
+	if err != nil {
+		return ContainerlabTopology{}, errors.Wrap(err, "failed to unmarshal containerlab topology")
+	}
+	if topology.Name == "" {
+		return ContainerlabTopology{}, errors.New("containerlab topology has no name")
+	}
+	return topology, nil
+}
+
+/*
+Marshal returns the topology in the .clab.yml format
+*/
+func (t ContainerlabTopology) Marshal() ([]byte, error) {
+	b, err := yaml.Marshal(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal containerlab topology")
+	}
+	return b, nil
+}
+
+/*
+NodeKind returns the kind of a node, falling back to the topology defaults
+*/
+func (t ContainerlabTopology) NodeKind(name string) string {
+	if kind := t.Topology.Nodes[name].Kind; kind != "" {
+		return kind
+	}
+	if t.Topology.Defaults != nil {
+		return t.Topology.Defaults.Kind
+	}
+	return ""
+}
+
+/*
+ImportContainerlab creates an eve-ng lab in the given folder containing the nodes and links of a containerlab
+topology. Bridge nodes become eve-ng bridge networks. Nodes whose kind has no mapping are skipped and listed in the
+returned report. If a link can not be connected, the network created for it is removed again and the link is listed
+as skipped.
+*/
+func (c *EveNgClient) ImportContainerlab(folder FolderPath, topology ContainerlabTopology, mappings KindMappings) (ContainerlabReport, error) {
+	report := ContainerlabReport{
+		LabPath:    folder.Lab(topology.Name),
+		NodeIDs:    make(map[string]int),
+		NetworkIDs: make(map[string]int),
+	}
+	err := c.AddLab(folder, topology.Name, "1", "", "Imported from containerlab", "")
+	if err != nil {
+		return report, errors.Wrap(err, "error while adding lab")
+	}
+
+	names := make([]string, 0, len(topology.Topology.Nodes))
+	for name := range topology.Topology.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bridgeIDs := make(map[string]int)
+	for i, name := range names {
+		node := topology.Topology.Nodes[name]
+		kind := topology.NodeKind(name)
+		if kind == clabBridgeKind {
+			left, top := clabNodePosition(node, i)
+			networkID, err := c.AddNetwork(report.LabPath, "bridge", name, left, top, 0, 0)
+			if err != nil {
+				return report, errors.Wrap(err, "error while adding network for bridge "+name)
+			}
+			bridgeIDs[name] = networkID
+			report.NetworkIDs[name] = networkID
+			continue
+		}
+		mapping, ok := mappings[kind]
+		if !ok {
+			report.UnmappedNodes = append(report.UnmappedNodes, name+" (kind "+kind+")")
+			continue
+		}
+		left, top := clabNodePosition(node, i)
+		nodeID, err := c.AddNode(report.LabPath, mapping.NodeType, mapping.Template, "0", 0, mapping.Icon, mapping.Image, name, left, top, mapping.RAM, mapping.Console, mapping.CPU, "undefined", mapping.Ethernet, "", "", "", "", 1)
+		if err != nil {
+			return report, errors.Wrap(err, "error while adding node "+name)
+		}
+		report.NodeIDs[name] = nodeID
+
+		if node.StartupConfig != "" {
+			configPath := node.StartupConfig
+			if !filepath.IsAbs(configPath) {
+				configPath = filepath.Join(topology.BaseDir, configPath)
+			}
+			err = c.SetNodeStartupConfig(report.LabPath, nodeID, configPath)
+			if err != nil {
+				report.Warnings = append(report.Warnings, "startup-config of node "+name+" not imported: "+err.Error())
+			}
+		}
+	}
+
+	for i, link := range topology.Topology.Links {
+		description := strings.Join(link.Endpoints, " <-> ")
+		if len(link.Endpoints) != 2 {
+			report.SkippedLinks = append(report.SkippedLinks, description+" (link needs exactly two endpoints)")
+			continue
+		}
+		type endpoint struct {
+			node          string
+			nodeID        int
+			interfaceName string
+		}
+		var endpoints []endpoint
+		bridgeID := 0
+		for _, e := range link.Endpoints {
+			parts := strings.SplitN(e, ":", 2)
+			if len(parts) != 2 {
+				break
+			}
+			if id, ok := bridgeIDs[parts[0]]; ok && bridgeID == 0 {
+				bridgeID = id
+				continue
+			}
+			nodeID, ok := report.NodeIDs[parts[0]]
+			if !ok {
+				break
+			}
+			endpoints = append(endpoints, endpoint{node: parts[0], nodeID: nodeID, interfaceName: parts[1]})
+		}
+		if len(endpoints) != 2 && (bridgeID == 0 || len(endpoints) != 1) {
+			report.SkippedLinks = append(report.SkippedLinks, description+" (endpoint is invalid or its node was not imported)")
+			continue
+		}
+
+		networkID := bridgeID
+		if networkID == 0 {
+			left, top := clabNodePosition(ContainerlabNode{}, len(names)+i)
+			networkID, err = c.AddNetwork(report.LabPath, "bridge", description, left, top, 0, 0)
+			if err != nil {
+				return report, errors.Wrap(err, "error while adding network for link "+description)
+			}
+			report.NetworkIDs[description] = networkID
+		}
+
+		for _, e := range endpoints {
+			mapping := mappings[topology.NodeKind(e.node)]
+			err = c.connectClabInterface(report.LabPath, e.nodeID, e.interfaceName, mapping.InterfaceOffset, networkID)
+			if err == nil {
+				continue
+			}
+			reason := err.Error()
+			if bridgeID == 0 {
+				// the network would be left connected to the other end only
+				removeErr := c.RemoveNetwork(report.LabPath, networkID)
+				if removeErr != nil {
+					reason += ", network " + strconv.Itoa(networkID) + " is left half connected: " + removeErr.Error()
+				} else {
+					delete(report.NetworkIDs, description)
+				}
+			}
+			report.SkippedLinks = append(report.SkippedLinks, description+" ("+reason+")")
+			break
+		}
+	}
+	return report, nil
+}
+
+/*
+ExportContainerlab builds a containerlab topology from an eve-ng lab. Nodes whose template has no equivalent kind
+are left out and listed in the returned report. Networks with more than two attached nodes are exported as
+containerlab bridge nodes.
+*/
+func (c *EveNgClient) ExportContainerlab(labPath LabPath, mappings KindMappings) (ContainerlabTopology, ContainerlabReport, error) {
+	report := ContainerlabReport{LabPath: labPath}
+	lab, err := c.GetLab(labPath)
+	if err != nil {
+		return ContainerlabTopology{}, report, errors.Wrap(err, "error while retrieving lab")
+	}
+	graph, err := c.GetTopologyGraph(labPath)
+	if err != nil {
+		return ContainerlabTopology{}, report, errors.Wrap(err, "error while building topology graph")
+	}
+	topology := ExportContainerlabTopology(lab.Name, graph, mappings, &report)
+	return topology, report, nil
+}
+
+/*
+ExportContainerlabTopology converts a topology graph into a containerlab topology. Everything that could not be
+converted is added to the given report.
+*/
+func ExportContainerlabTopology(name string, graph *Graph, mappings KindMappings, report *ContainerlabReport) ContainerlabTopology {
+	kinds := make(map[string]string)
+	kindNames := make([]string, 0, len(mappings))
+	for kind := range mappings {
+		kindNames = append(kindNames, kind)
+	}
+	// the first kind in alphabetical order wins if several kinds map to the same template
+	sort.Sort(sort.Reverse(sort.StringSlice(kindNames)))
+	for _, kind := range kindNames {
+		kinds[mappings[kind].Template] = kind
+	}
+
+	topology := ContainerlabTopology{
+		Name: name,
+		Topology: ContainerlabTopologySpec{
+			Nodes: make(map[string]ContainerlabNode),
+		},
+	}
+	clabNames := make(map[int]string)
+	for _, v := range graph.Vertices() {
+		if v.Kind != VertexKindNode {
+			continue
+		}
+		node := graph.Nodes[v.ID]
+		kind, ok := kinds[node.Template]
+		if !ok {
+			report.UnmappedNodes = append(report.UnmappedNodes, node.Name+" (template "+node.Template+")")
+			continue
+		}
+		clabName := clabNodeName(node.Name, node.ID, topology.Topology.Nodes)
+		clabNames[node.ID] = clabName
+		topology.Topology.Nodes[clabName] = ContainerlabNode{
+			Kind:  kind,
+			Image: mappings[kind].ContainerImage,
+			Labels: map[string]string{
+				clabPositionXLabel: strconv.Itoa(node.Left),
+				clabPositionYLabel: strconv.Itoa(node.Top),
+			},
+		}
+	}
+
+	clabEndpoint := func(e Endpoint) (string, bool) {
+		clabName, ok := clabNames[e.ID]
+		if !ok {
+			return "", false
+		}
+		kind := topology.Topology.Nodes[clabName].Kind
+		return clabName + ":" + clabInterfaceName(e.InterfaceID, mappings[kind].InterfaceOffset), true
+	}
+
+	attached := make(map[int][]Endpoint)
+	for _, link := range graph.Links {
+		description := link.Source.String() + " <-> " + link.Destination.String()
+		if link.Type == string(InterfaceTypeSerial) {
+			report.SkippedLinks = append(report.SkippedLinks, description+" (serial links are not supported by containerlab)")
+			continue
+		}
+		if link.Source.Kind == VertexKindNode && link.Destination.Kind == VertexKindNode {
+			a, okA := clabEndpoint(link.Source)
+			b, okB := clabEndpoint(link.Destination)
+			if !okA || !okB {
+				report.SkippedLinks = append(report.SkippedLinks, description+" (node was not exported)")
+				continue
+			}
+			topology.Topology.Links = append(topology.Topology.Links, ContainerlabLink{Endpoints: []string{a, b}})
+			continue
+		}
+		if link.Source.Kind == VertexKindNode && link.Destination.Kind == VertexKindNetwork {
+			attached[link.Destination.ID] = append(attached[link.Destination.ID], link.Source)
+		}
+	}
+
+	networkIDs := make([]int, 0, len(attached))
+	for id := range attached {
+		networkIDs = append(networkIDs, id)
+	}
+	sort.Ints(networkIDs)
+	for _, networkID := range networkIDs {
+		var endpoints []string
+		for _, e := range attached[networkID] {
+			clabName, ok := clabEndpoint(e)
+			if !ok {
+				report.SkippedLinks = append(report.SkippedLinks, e.String()+" <-> network"+strconv.Itoa(networkID)+" (node was not exported)")
+				continue
+			}
+			endpoints = append(endpoints, clabName)
+		}
+		switch {
+		case len(endpoints) < 2:
+			report.Warnings = append(report.Warnings, "network"+strconv.Itoa(networkID)+" has less than two exported nodes attached and was left out")
+		case len(endpoints) == 2:
+			topology.Topology.Links = append(topology.Topology.Links, ContainerlabLink{Endpoints: endpoints})
+		default:
+			bridgeName := clabNodeName("br-"+graph.Networks[networkID].Name, networkID, topology.Topology.Nodes)
+			topology.Topology.Nodes[bridgeName] = ContainerlabNode{Kind: clabBridgeKind}
+			for i, e := range endpoints {
+				topology.Topology.Links = append(topology.Topology.Links, ContainerlabLink{Endpoints: []string{e, bridgeName + ":eth" + strconv.Itoa(i+1)}})
+			}
+			report.Warnings = append(report.Warnings, "network"+strconv.Itoa(networkID)+" was exported as bridge "+bridgeName+" which has to exist on the containerlab host")
+		}
+	}
+	return topology
+}
+
+//---------- helper functions ----------//
+
+/*
+connectClabInterface - Connects a node interface given in containerlab notation to a network. Linux interface names
+like "eth1" are mapped by their number, other names like "e1-1" are looked up by name first and by their number
+otherwise.
+*/
+func (c *EveNgClient) connectClabInterface(labPath LabPath, nodeID int, interfaceName string, interfaceOffset int, networkID int) error {
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	if index, ok := clabInterfaceIndex(interfaceName, interfaceOffset); ok {
+		if index < 0 || index >= len(interfaces.Ethernet) {
+			return errors.New("interface " + interfaceName + " is the management interface or out of range")
+		}
+		return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, interfaces.Ethernet[index].ID, networkID)
+	}
+	iface, err := interfaces.FindInterfaceOfType(interfaceName, InterfaceTypeEthernet)
+	if err == nil {
+		return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, iface.ID, networkID)
+	}
+	match := clabInterfaceNumber.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return err
+	}
+	// containerlab numbers data interfaces from 1, e.g. "e1-1" or "Ethernet1"
+	number, _ := strconv.Atoi(match[1])
+	index := number - 1 + interfaceOffset
+	if index < 0 || index >= len(interfaces.Ethernet) {
+		return errors.Wrap(err, "interface index "+strconv.Itoa(index)+" out of range")
+	}
+	return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, interfaces.Ethernet[index].ID, networkID)
+}
+
+/*
+clabInterfaceName - Returns the containerlab name of an eve-ng interface index, data interfaces start at eth1
+*/
+func clabInterfaceName(index int, interfaceOffset int) string {
+	return "eth" + strconv.Itoa(index-interfaceOffset+1)
+}
+
+/*
+clabInterfaceIndex - Returns the eve-ng interface index of a containerlab interface like "eth1", which is negative for
+the management interface eth0. It returns false for other interface names.
+*/
+func clabInterfaceIndex(interfaceName string, interfaceOffset int) (int, bool) {
+	match := clabEthInterface.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return 0, false
+	}
+	number, _ := strconv.Atoi(match[1])
+	return number - 1 + interfaceOffset, true
+}
+
+/*
+clabNodePosition - Returns the position of a node taken from its containerlab graph labels or a grid position
+*/
+func clabNodePosition(node ContainerlabNode, index int) (int, int) {
+	left, errLeft := strconv.Atoi(node.Labels[clabPositionXLabel])
+	top, errTop := strconv.Atoi(node.Labels[clabPositionYLabel])
+	if errLeft == nil && errTop == nil {
+		return left, top
+	}
+	return 100 + (index%6)*150, 100 + (index/6)*150
+}
+
+/*
+clabNodeName - Converts a name into a unique containerlab node name
+*/
+func clabNodeName(name string, id int, existing map[string]ContainerlabNode) string {
+	clabName := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	if clabName == "" {
+		clabName = "node"
+	}
+	if _, ok := existing[clabName]; ok {
+		clabName += "-" + strconv.Itoa(id)
+	}
+	return clabName
+}
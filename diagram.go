@@ -0,0 +1,225 @@
+package evengclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+DiagramFormat is an output format for topology diagrams
+*/
+type DiagramFormat string
+
+const (
+	// DiagramFormatDOT renders a Graphviz DOT graph, node positions are honored by the neato and fdp layouts
+	DiagramFormatDOT DiagramFormat = "dot"
+	// DiagramFormatMermaid renders a Mermaid flowchart
+	DiagramFormatMermaid DiagramFormat = "mermaid"
+	// DiagramFormatJSON renders a D3 friendly json document containing nodes and links
+	DiagramFormatJSON DiagramFormat = "json"
+)
+
+/*
+DiagramData is the D3 friendly representation of a topology graph
+*/
+type DiagramData struct {
+	Nodes []DiagramVertex `json:"nodes"`
+	Links []DiagramLink   `json:"links"`
+}
+
+/*
+DiagramVertex is a node or network in a D3 friendly topology diagram
+*/
+type DiagramVertex struct {
+	ID       string     `json:"id"`
+	Kind     VertexKind `json:"kind"`
+	Name     string     `json:"name"`
+	Type     string     `json:"type"`
+	Template string     `json:"template,omitempty"`
+	Image    string     `json:"image,omitempty"`
+	Icon     string     `json:"icon,omitempty"`
+	Status   int        `json:"status"`
+	X        int        `json:"x"`
+	Y        int        `json:"y"`
+}
+
+/*
+DiagramLink is a link in a D3 friendly topology diagram
+*/
+type DiagramLink struct {
+	Source          string `json:"source"`
+	Target          string `json:"target"`
+	Type            string `json:"type"`
+	SourceInterface string `json:"source_interface,omitempty"`
+	TargetInterface string `json:"target_interface,omitempty"`
+	NetworkID       int    `json:"network_id,omitempty"`
+	Label           string `json:"label,omitempty"`
+}
+
+/*
+RenderTopology renders the topology of the given lab in the given diagram format
+*/
+func (c *EveNgClient) RenderTopology(labPath LabPath, format DiagramFormat) ([]byte, error) {
+	graph, err := c.GetTopologyGraph(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while building topology graph")
+	}
+	return graph.Render(format)
+}
+
+/*
+Render renders the graph in the given diagram format
+*/
+func (g *Graph) Render(format DiagramFormat) ([]byte, error) {
+	switch format {
+	case DiagramFormatDOT:
+		return []byte(g.DOT()), nil
+	case DiagramFormatMermaid:
+		return []byte(g.Mermaid()), nil
+	case DiagramFormatJSON:
+		b, err := json.MarshalIndent(g.DiagramData(), "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal diagram data to json")
+		}
+		return b, nil
+	}
+	return nil, errors.New("invalid diagram format: " + string(format))
+}
+
+/*
+DOT returns the graph as Graphviz DOT. Nodes are drawn as boxes, networks as ellipses and every edge is labeled
+with the node interfaces it connects.
+*/
+func (g *Graph) DOT() string {
+	var buf bytes.Buffer
+	buf.WriteString("graph lab {\n")
+	buf.WriteString("\tnode [shape=box];\n")
+	for _, v := range g.Vertices() {
+		vertex := g.diagramVertex(v)
+		buf.WriteString("\t" + vertex.ID + " [label=" + dotQuote(vertex.Name))
+		if v.Kind == VertexKindNetwork {
+			buf.WriteString(", shape=ellipse")
+		}
+		if vertex.Icon != "" {
+			buf.WriteString(", icon=" + dotQuote(vertex.Icon))
+		}
+		buf.WriteString(", pos=" + dotQuote(strconv.Itoa(vertex.X)+","+strconv.Itoa(-vertex.Y)+"!") + "];\n")
+	}
+	for _, link := range g.Links {
+		buf.WriteString("\t" + link.Source.String() + " -- " + link.Destination.String())
+		var attributes []string
+		if link.Source.InterfaceLabel != "" {
+			attributes = append(attributes, "taillabel="+dotQuote(link.Source.InterfaceLabel))
+		}
+		if link.Destination.InterfaceLabel != "" {
+			attributes = append(attributes, "headlabel="+dotQuote(link.Destination.InterfaceLabel))
+		}
+		if link.Type == string(InterfaceTypeSerial) {
+			attributes = append(attributes, "style=dashed")
+		}
+		if len(attributes) > 0 {
+			buf.WriteString(" [" + strings.Join(attributes, ", ") + "]")
+		}
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+/*
+Mermaid returns the graph as Mermaid flowchart. Nodes are drawn as rectangles and networks as circles.
+*/
+func (g *Graph) Mermaid() string {
+	var buf bytes.Buffer
+	buf.WriteString("flowchart LR\n")
+	for _, v := range g.Vertices() {
+		vertex := g.diagramVertex(v)
+		if v.Kind == VertexKindNetwork {
+			buf.WriteString("    " + vertex.ID + "((" + mermaidQuote(vertex.Name) + "))\n")
+			continue
+		}
+		buf.WriteString("    " + vertex.ID + "[" + mermaidQuote(vertex.Name) + "]\n")
+	}
+	for _, link := range g.Links {
+		arrow := " --- "
+		if link.Type == string(InterfaceTypeSerial) {
+			arrow = " -.- "
+		}
+		label := strings.Trim(link.Source.InterfaceLabel+" / "+link.Destination.InterfaceLabel, " /")
+		if label != "" {
+			arrow = arrow[:len(arrow)-1] + "|" + mermaidQuote(label) + "| "
+		}
+		buf.WriteString("    " + link.Source.String() + arrow + link.Destination.String() + "\n")
+	}
+	return buf.String()
+}
+
+/*
+DiagramData returns the graph as D3 friendly nodes and links, positions are taken from the lab
+*/
+func (g *Graph) DiagramData() DiagramData {
+	data := DiagramData{
+		Nodes: make([]DiagramVertex, 0, len(g.Nodes)+len(g.Networks)),
+		Links: make([]DiagramLink, 0, len(g.Links)),
+	}
+	for _, v := range g.Vertices() {
+		data.Nodes = append(data.Nodes, g.diagramVertex(v))
+	}
+	for _, link := range g.Links {
+		data.Links = append(data.Links, DiagramLink{
+			Source:          link.Source.String(),
+			Target:          link.Destination.String(),
+			Type:            link.Type,
+			SourceInterface: link.Source.InterfaceLabel,
+			TargetInterface: link.Destination.InterfaceLabel,
+			NetworkID:       link.NetworkID,
+			Label:           link.Label,
+		})
+	}
+	return data
+}
+
+//---------- helper functions ----------//
+
+/*
+diagramVertex - Collects the diagram relevant data of a vertex
+*/
+func (g *Graph) diagramVertex(v Vertex) DiagramVertex {
+	vertex := DiagramVertex{ID: v.String(), Kind: v.Kind}
+	switch v.Kind {
+	case VertexKindNode:
+		if node, ok := g.Nodes[v.ID]; ok {
+			vertex.Name = node.Name
+			vertex.Type = node.Type
+			vertex.Template = node.Template
+			vertex.Image = node.Image
+			vertex.Icon = node.Icon
+			vertex.Status = node.Status
+			vertex.X = node.Left
+			vertex.Y = node.Top
+		}
+	case VertexKindNetwork:
+		if network, ok := g.Networks[v.ID]; ok {
+			vertex.Name = network.Name
+			vertex.Type = network.Type
+			vertex.X = network.Left
+			vertex.Y = network.Top
+		}
+	}
+	if vertex.Name == "" {
+		vertex.Name = vertex.ID
+	}
+	return vertex
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
@@ -1,25 +1,58 @@
 package evengclient
 
 import (
-	"github.com/spf13/viper"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
+/*
+testProfile returns the server of the integration tests, which is taken from the environment or the legacy config
+file config/eve-ng-api.yaml
+*/
+func testProfile() Profile {
+	return EnvironmentProfile()
+}
+
+/*
+testCredentials returns the credentials of the integration tests
+*/
+func testCredentials() Credentials {
+	credentials, _ := testProfile().ResolveCredentials()
+	return credentials
+}
+
 /*
 TestEveNgClient_LoginLogout covers:
 	- Login
 	- Logout
 */
 func TestEveNgClient_LoginLogout(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -41,12 +74,12 @@ TestEveNgClient_GetSystemStatus covers:
 	- GetSystemStatus
 */
 func TestEveNgClient_GetSystemStatus(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -83,12 +116,12 @@ TestEveNgClient_GetNodeTemplates covers:
 	- GetNodeTemplate
 */
 func TestEveNgClient_NodeTemplates(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -134,8 +167,7 @@ func TestEveNgClient_NodeTemplates(t *testing.T) {
 			assert.NotEmpty(t, nodeTemplate.Options.Icon.Type, "Node template options icon type is empty")
 			assert.NotEmpty(t, nodeTemplate.Options.Icon.Value, "Node template options icon value is empty")
 			//Options.Image
-			var interfaceType []interface{}
-			assert.IsType(t, interfaceType, nodeTemplate.Options.Image.List, "Node template options image list is not an actual []interface{}")
+			assert.IsType(t, ImageList{}, nodeTemplate.Options.Image.List, "Node template options image list is not an actual image list")
 			assert.NotEmpty(t, nodeTemplate.Options.Image.Name, "Node template options image name is empty")
 			assert.NotEmpty(t, nodeTemplate.Options.Image.Type, "Node template options image type is empty")
 			assert.NotNil(t, nodeTemplate.Options.Image.Value, "Node template options image value is nil")
@@ -217,12 +249,12 @@ TestEveNgClient_getFolderContents covers:
 	- getFolderContents
 */
 func TestEveNgClient_getFolderContents(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -250,12 +282,12 @@ TestEveNgClient_GetLabFiles covers:
 	- GetLabFiles
 */
 func TestEveNgClient_GetLabFiles(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -282,12 +314,12 @@ TestEveNgClient_GetFolders covers:
 	- GetFolders
 */
 func TestEveNgClient_GetFolders(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -314,12 +346,12 @@ TestEveNgClient_GetUserRoles covers:
 	- GetUserRoles
 */
 func TestEveNgClient_GetUserRoles(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -356,12 +388,12 @@ TestEveNgClient_Users covers:
 	- RemoveUser
 */
 func TestEveNgClient_Users(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -432,12 +464,12 @@ TestEveNgClient_GetNetworkTypes covers:
 	- GetNetworkTypes
 */
 func TestEveNgClient_GetNetworkTypes(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -473,12 +505,12 @@ TestEveNgClient_Folders covers:
 	- MoveFolder
 */
 func TestEveNgClient_Folders(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -494,7 +526,7 @@ func TestEveNgClient_Folders(t *testing.T) {
 		}
 	}()
 
-	folderPath := ""
+	folderPath := RootFolder
 	folderName := "FolderTesting"
 	foundFolders := 0
 
@@ -507,13 +539,13 @@ func TestEveNgClient_Folders(t *testing.T) {
 			foundFolders = len(foldersAfterAdd)
 		}
 
-		testFolders, err := eveNgClient.GetFolders("/" + folderName)
+		testFolders, err := eveNgClient.GetFolders(FolderPath("/" + folderName))
 		if assert.NoError(t, err, "Error during GetFolders operation") {
 			assert.True(t, len(testFolders) > 0, "No folders found insider of TestFolder during GetFolders operation")
 		}
 	}
 	defer func() {
-		err = eveNgClient.RemoveFolder(folderName)
+		err = eveNgClient.RemoveFolder(FolderPath(folderName))
 		if assert.NoError(t, err, "Error during RemoveFolder operation") {
 			foldersAfterRemove, err := eveNgClient.GetFolders("")
 			if assert.NoError(t, err, "Error during GetFolders operation") {
@@ -523,7 +555,7 @@ func TestEveNgClient_Folders(t *testing.T) {
 		}
 	}()
 
-	newFolderPath := ""
+	newFolderPath := RootFolder
 	newFolderName := "FolderTestingMove"
 	newFoundFolders := 0
 
@@ -536,13 +568,13 @@ func TestEveNgClient_Folders(t *testing.T) {
 			newFoundFolders = len(foldersAfterAdd)
 		}
 
-		testFolders, err := eveNgClient.GetFolders(folderName)
+		testFolders, err := eveNgClient.GetFolders(FolderPath(folderName))
 		if assert.NoError(t, err, "Error during GetFolders operation") {
 			assert.True(t, len(testFolders) > 0, "No folders found insider of TestFolder during GetFolders operation")
 		}
 	}
 	defer func() {
-		err = eveNgClient.RemoveFolder(newFolderName)
+		err = eveNgClient.RemoveFolder(FolderPath(newFolderName))
 		if assert.NoError(t, err, "Error during RemoveFolder operation") {
 			foldersAfterRemove, err := eveNgClient.GetFolders("")
 			if assert.NoError(t, err, "Error during GetFolders operation") {
@@ -555,15 +587,15 @@ func TestEveNgClient_Folders(t *testing.T) {
 	//Move folder and check if it worked correctly
 	foldersInOldFolderBeforeMove, err := eveNgClient.GetFolders("")
 	assert.NoError(t, err, "Error during GetFolders operation")
-	foldersInNewFolderBeforeMove, err := eveNgClient.GetFolders(newFolderName)
+	foldersInNewFolderBeforeMove, err := eveNgClient.GetFolders(FolderPath(newFolderName))
 	assert.NoError(t, err, "Error during GetFolders operation")
-	err = eveNgClient.MoveFolder(folderName, "/"+newFolderName+"/"+folderName)
+	err = eveNgClient.MoveFolder(FolderPath(folderName), FolderPath("/"+newFolderName+"/"+folderName))
 	if assert.NoError(t, err, "Error during MoveFolder operation") {
 		foldersInOldFolderAfterMove, err := eveNgClient.GetFolders("")
 		if assert.NoError(t, err, "Error during FetFolders operation") {
 			assert.Less(t, len(foldersInOldFolderAfterMove), len(foldersInOldFolderBeforeMove), "Folder hasn't been moved correctly")
 		}
-		foldersInNewFolderAfterMove, err := eveNgClient.GetFolders(newFolderName)
+		foldersInNewFolderAfterMove, err := eveNgClient.GetFolders(FolderPath(newFolderName))
 		if assert.NoError(t, err, "Error during GetFolders operation") {
 			assert.Greater(t, len(foldersInNewFolderAfterMove), len(foldersInNewFolderBeforeMove), "Folder hasn't been moved correctly")
 		}
@@ -571,15 +603,15 @@ func TestEveNgClient_Folders(t *testing.T) {
 	defer func() {
 		foldersInOldFolderBefore2ndMove, err := eveNgClient.GetFolders("")
 		assert.NoError(t, err, "Error during GetFolders operation")
-		foldersInNewFolderBefore2ndMove, err := eveNgClient.GetFolders(newFolderName)
+		foldersInNewFolderBefore2ndMove, err := eveNgClient.GetFolders(FolderPath(newFolderName))
 		assert.NoError(t, err, "Error during GetFolders operation")
-		err = eveNgClient.MoveFolder(newFolderName+"/"+folderName, "/"+folderName)
+		err = eveNgClient.MoveFolder(FolderPath(newFolderName+"/"+folderName), FolderPath("/"+folderName))
 		if assert.NoError(t, err, "Error during MoveFolder operation") {
 			foldersInOldFolderAfter2ndMove, err := eveNgClient.GetFolders("")
 			if assert.NoError(t, err, "Error during FetFolders operation") {
 				assert.Greater(t, len(foldersInOldFolderAfter2ndMove), len(foldersInOldFolderBefore2ndMove), "Folder hasn't been moved correctly")
 			}
-			foldersInNewFolderAfter2ndMove, err := eveNgClient.GetFolders(newFolderName)
+			foldersInNewFolderAfter2ndMove, err := eveNgClient.GetFolders(FolderPath(newFolderName))
 			if assert.NoError(t, err, "Error during GetFolders operation") {
 				assert.Less(t, len(foldersInNewFolderAfter2ndMove), len(foldersInNewFolderBefore2ndMove), "Folder hasn't been moved correctly")
 			}
@@ -601,12 +633,12 @@ TestEveNgClient_Labs covers:
 	- RemoveLabNetwork
 */
 func TestEveNgClient_Labs(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -623,9 +655,9 @@ func TestEveNgClient_Labs(t *testing.T) {
 	}()
 
 	//Add, Get, Remove Lab test functions
-	labFolder := ""
+	labFolder := RootFolder
 	labName := "LabTesting2"
-	labPath := labName + ".unl"
+	labPath := LabPath(labName + ".unl")
 	foundLabs := 0
 
 	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
@@ -661,7 +693,7 @@ func TestEveNgClient_Labs(t *testing.T) {
 
 	err = eveNgClient.EditLab(labPath, renamedTestLabName, "2", "testauthor", "A changed test laboratory")
 	if assert.NoError(t, err, "Error during EditLab operation") {
-		editedTestLab, err := eveNgClient.GetLab(renamedTestLabName + ".unl")
+		editedTestLab, err := eveNgClient.GetLab(LabPath(renamedTestLabName + ".unl"))
 		if assert.NoError(t, err, "Error during GetLab operation") {
 			assert.NotEmpty(t, editedTestLab.ID, "Lab ID is empty")
 			assert.Equal(t, "testauthor", editedTestLab.Author, "TestLab author does not match expected value")
@@ -671,7 +703,7 @@ func TestEveNgClient_Labs(t *testing.T) {
 			assert.Equal(t, "2", editedTestLab.Version, "TestLab version does not match expected value")
 		}
 	}
-	err = eveNgClient.EditLab(renamedTestLabName+".unl", labName, "2", "testauthor", "A changed test laboratory")
+	err = eveNgClient.EditLab(LabPath(renamedTestLabName+".unl"), labName, "2", "testauthor", "A changed test laboratory")
 	if assert.NoError(t, err, "Error during EditLab operation") {
 		testLab, err := eveNgClient.GetLab(labPath)
 		if assert.NoError(t, err, "Error during GetLab operation") {
@@ -719,14 +751,14 @@ func TestEveNgClient_Labs(t *testing.T) {
 
 	//Add a new folder and remove it afterwards
 	folderName := "FolderTesting"
-	folderPath := "/" + folderName
+	folderPath := FolderPath("/" + folderName)
 	err = eveNgClient.AddFolder("", folderName)
 	defer func() {
-		err = eveNgClient.RemoveFolder("/" + folderName)
+		err = eveNgClient.RemoveFolder(FolderPath("/" + folderName))
 	}()
 
 	//MoveLab
-	newLabPath := folderPath + "/" + labName + ".unl"
+	newLabPath := folderPath.Lab(labName + ".unl")
 
 	labFilesInOldFolderBeforeMove, err := eveNgClient.GetLabFiles("")
 	labFilesInNewFolderBeforeMove, err := eveNgClient.GetLabFiles(folderPath)
@@ -777,12 +809,12 @@ TestEveNgClient_Nodes covers:
 	- GetLabTopology
 */
 func TestEveNgClient_Nodes(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -799,9 +831,9 @@ func TestEveNgClient_Nodes(t *testing.T) {
 	}()
 
 	//Add a new lab
-	labFolder := ""
+	labFolder := RootFolder
 	labName := "NodeTesting"
-	labPath := labName + ".unl"
+	labPath := LabPath(labName + ".unl")
 	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
 	defer func() {
 		err = eveNgClient.RemoveLab(labPath)
@@ -964,6 +996,14 @@ func TestEveNgClient_Nodes(t *testing.T) {
 			}
 		}
 	}
+
+	//Get lab topology graph
+	labGraph, err := eveNgClient.GetTopologyGraph(labPath)
+	if assert.NoError(t, err, "Error during GetTopologyGraph operation") {
+		assert.Len(t, labGraph.Links, len(labTopology), "Number of graph links does not match topology")
+		assert.Contains(t, labGraph.Nodes, nodeID, "Node is missing in topology graph")
+		assert.Contains(t, labGraph.Networks, networkID, "Network is missing in topology graph")
+	}
 }
 
 /*
@@ -974,12 +1014,12 @@ TestEveNgClient_ExportWipeNodes covers:
 	- WipeLabNodes
 */
 func TestEveNgClient_ExportWipeNodes(t *testing.T) {
-	eveNgClient, err := NewEveNgClient(viper.GetString("BaseURL"))
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
 	if !assert.NoError(t, err, "Error while creating API client") {
 		return
 	}
 
-	err = eveNgClient.SetUsernameAndPassword(viper.GetString("Username"), viper.GetString("Password"))
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
 	if !assert.NoError(t, err, "Error while setting username and password") {
 		return
 	}
@@ -996,9 +1036,9 @@ func TestEveNgClient_ExportWipeNodes(t *testing.T) {
 	}()
 
 	//Add a new lab
-	labFolder := ""
+	labFolder := RootFolder
 	labName := "NodeWipeAndExportTesting"
-	labPath := labName + ".unl"
+	labPath := LabPath(labName + ".unl")
 	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
 	defer func() {
 		err = eveNgClient.RemoveLab(labPath)
@@ -1025,3 +1065,2070 @@ func TestEveNgClient_ExportWipeNodes(t *testing.T) {
 	err = eveNgClient.WipeNodes(labPath)
 	assert.NoError(t, err, "Error during WipeNodes operation")
 }
+
+/*
+TestInterfaces_FindInterface covers:
+	- EthernetInterfaces.UnmarshalJSON
+	- SerialInterfaces.UnmarshalJSON
+	- FindInterface
+	- FindInterfaceOfType
+*/
+func TestInterfaces_FindInterface(t *testing.T) {
+	var interfaces Interfaces
+	err := json.Unmarshal([]byte(`{"ethernet":{"0":{"name":"e0/0","network_id":1},"16":{"name":"e0/1","network_id":0}},"serial":[{"name":"s1/0","remote_id":0,"remote_if":0}]}`), &interfaces)
+	if !assert.NoError(t, err, "Error during unmarshal of iol interfaces") {
+		return
+	}
+	if assert.Len(t, interfaces.Ethernet, 2, "Ethernet interfaces were not decoded correctly") {
+		assert.Equal(t, 16, interfaces.Ethernet[1].ID, "Ethernet interface id does not match its key")
+	}
+
+	iface, interfaceType, err := interfaces.FindInterface("E0/1")
+	if assert.NoError(t, err, "Error during FindInterface operation") {
+		assert.Equal(t, 16, iface.ID, "Found interface id does not match expected value")
+		assert.Equal(t, InterfaceTypeEthernet, interfaceType, "Found interface type does not match expected value")
+	}
+
+	iface, interfaceType, err = interfaces.FindInterface("Serial1/0")
+	if assert.NoError(t, err, "Error during FindInterface operation") {
+		assert.Equal(t, 0, iface.ID, "Found interface id does not match expected value")
+		assert.Equal(t, InterfaceTypeSerial, interfaceType, "Found interface type does not match expected value")
+	}
+
+	_, _, err = interfaces.FindInterface("e0/2")
+	if assert.Error(t, err, "FindInterface found a non existing interface") {
+		assert.Contains(t, err.Error(), "e0/0, e0/1", "Error does not list valid interface names")
+	}
+
+	err = json.Unmarshal([]byte(`{"ethernet":[{"name":"Mgmt1","network_id":0},{"name":"Eth1","network_id":0},{"name":"Eth2","network_id":0}],"serial":[]}`), &interfaces)
+	if !assert.NoError(t, err, "Error during unmarshal of qemu interfaces") {
+		return
+	}
+	iface, err = interfaces.FindInterfaceOfType("Ethernet2", InterfaceTypeEthernet)
+	if assert.NoError(t, err, "Error during FindInterfaceOfType operation") {
+		assert.Equal(t, 2, iface.ID, "Found interface id does not match expected value")
+	}
+}
+
+/*
+TestEveNgClient_NodeInterfacesByName covers:
+	- GetNodeInterface
+	- ConnectNodeInterfaceToNetworkByName
+	- DisconnectNodeInterfaceByName
+*/
+func TestEveNgClient_NodeInterfacesByName(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	//Add a new lab
+	labFolder := RootFolder
+	labName := "InterfaceNameTesting"
+	labPath := LabPath(labName + ".unl")
+	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	defer func() {
+		err = eveNgClient.RemoveLab(labPath)
+	}()
+
+	networkID, err := eveNgClient.AddNetwork(labPath, "bridge", "TestNetwork", 69, 420, 1, 0)
+	if !assert.NoError(t, err, "Error during AddNetwork operation") {
+		return
+	}
+	nodeID, err := eveNgClient.AddNode(labPath, "qemu", "asav", "0", 0, "ASA.png", "asav-952-204", "ASAv", 404, 227, 2048, "telnet", 1, "undefined", 8, "", "", "", "", 1)
+	if !assert.NoError(t, err, "Error during AddNode operation") {
+		return
+	}
+
+	nodeInterfaces, err := eveNgClient.GetNodeInterfaces(labPath, nodeID)
+	if !assert.NoError(t, err, "Error during GetNodeInterfaces operation") || !assert.True(t, len(nodeInterfaces.Ethernet) > 1, "Node has not enough interfaces") {
+		return
+	}
+	interfaceName := nodeInterfaces.Ethernet[1].Name
+
+	err = eveNgClient.ConnectNodeInterfaceToNetworkByName(labPath, nodeID, interfaceName, networkID)
+	if assert.NoError(t, err, "Error during ConnectNodeInterfaceToNetworkByName operation") {
+		iface, interfaceType, err := eveNgClient.GetNodeInterface(labPath, nodeID, interfaceName)
+		if assert.NoError(t, err, "Error during GetNodeInterface operation") && assert.NotNil(t, iface.NetworkID, "Interface is not connected") {
+			assert.Equal(t, InterfaceTypeEthernet, interfaceType, "Interface type does not match expected value")
+			assert.Equal(t, networkID, *iface.NetworkID, "Network was not correctly added to NodeInterface")
+		}
+	}
+
+	err = eveNgClient.DisconnectNodeInterfaceByName(labPath, nodeID, interfaceName)
+	assert.NoError(t, err, "Error during DisconnectNodeInterfaceByName operation")
+
+	err = eveNgClient.ConnectNodeInterfaceToNetworkByName(labPath, nodeID, "NoSuchInterface99", networkID)
+	assert.IsType(t, &InterfaceNotFoundError{}, errors.Cause(err), "Unknown interface name did not return an InterfaceNotFoundError")
+}
+
+/*
+TestGraph covers:
+	- NewGraph
+	- NeighborsOf
+	- PathBetween
+	- ConnectedComponents
+	- DanglingInterfaces
+	- IsolatedNodes
+*/
+func TestGraph(t *testing.T) {
+	nodes := Nodes{
+		"1": NodeWithID{ID: 1, Node: Node{Name: "R1"}},
+		"2": NodeWithID{ID: 2, Node: Node{Name: "R2"}},
+		"3": NodeWithID{ID: 3, Node: Node{Name: "R3"}},
+		"4": NodeWithID{ID: 4, Node: Node{Name: "R4"}},
+	}
+	networks := Networks{
+		"1": NetworkWithID{ID: 1, Network: Network{Name: "LAN"}},
+		"2": NetworkWithID{ID: 2, Network: Network{Name: "Stub"}},
+	}
+	topology := TopologyPoints{
+		{Type: "ethernet", Source: "node1", SourceInterfaceID: 0, SourceLabel: "e0/0", Destination: "network1", NetworkID: 1},
+		{Type: "ethernet", Source: "node2", SourceInterfaceID: 0, SourceLabel: "e0/0", Destination: "network1", NetworkID: 1},
+		{Type: "serial", Source: "node2", SourceInterfaceID: 32, SourceLabel: "s1/0", Destination: "node3", DestinationInterfaceID: "32", DestinationLabel: "s1/0"},
+		{Type: "ethernet", Source: "node3", SourceInterfaceID: 1, SourceLabel: "e0/1", Destination: "network2", NetworkID: 2},
+	}
+
+	graph, err := NewGraph(nodes, networks, topology)
+	if !assert.NoError(t, err, "Error during NewGraph operation") {
+		return
+	}
+	assert.Len(t, graph.Links, 4, "Number of links does not match expected value")
+	assert.Equal(t, []int{1, 3}, graph.NeighborsOf(2), "Neighbors of node 2 do not match expected value")
+
+	path := graph.PathBetween(Vertex{Kind: VertexKindNode, ID: 1}, Vertex{Kind: VertexKindNode, ID: 3})
+	assert.Equal(t, []Vertex{{VertexKindNode, 1}, {VertexKindNetwork, 1}, {VertexKindNode, 2}, {VertexKindNode, 3}}, path, "Path between node 1 and node 3 does not match expected value")
+	assert.Nil(t, graph.PathBetween(Vertex{Kind: VertexKindNode, ID: 1}, Vertex{Kind: VertexKindNode, ID: 4}), "Path to isolated node found")
+
+	components := graph.ConnectedComponents()
+	if assert.Len(t, components, 2, "Number of connected components does not match expected value") {
+		assert.Len(t, components[0], 5, "Size of largest component does not match expected value")
+	}
+
+	dangling := graph.DanglingInterfaces()
+	if assert.Len(t, dangling, 1, "Number of dangling interfaces does not match expected value") {
+		assert.Equal(t, 3, dangling[0].ID, "Dangling interface node does not match expected value")
+		assert.Equal(t, "e0/1", dangling[0].InterfaceLabel, "Dangling interface label does not match expected value")
+	}
+	assert.Equal(t, []int{4}, graph.IsolatedNodes(), "Isolated nodes do not match expected value")
+
+	_, err = NewGraph(nodes, networks, TopologyPoints{{Source: "cloud1", Destination: "network1"}})
+	assert.Error(t, err, "Invalid topology source was accepted")
+}
+
+/*
+TestGraph_Render covers:
+	- DOT
+	- Mermaid
+	- DiagramData
+	- Render
+*/
+func TestGraph_Render(t *testing.T) {
+	nodes := Nodes{"1": NodeWithID{ID: 1, Node: Node{Name: `Core "A"`, Icon: "Router.png", Left: 100, Top: 200}}}
+	networks := Networks{"1": NetworkWithID{ID: 1, Network: Network{Name: "LAN", Type: "bridge", Left: 300, Top: 200}}}
+	topology := TopologyPoints{{Type: "ethernet", Source: "node1", SourceInterfaceID: 0, SourceLabel: "e0/0", Destination: "network1", NetworkID: 1}}
+
+	graph, err := NewGraph(nodes, networks, topology)
+	if !assert.NoError(t, err, "Error during NewGraph operation") {
+		return
+	}
+
+	dot := graph.DOT()
+	assert.Contains(t, dot, `node1 [label="Core \"A\"", icon="Router.png", pos="100,-200!"];`, "DOT node does not match expected value")
+	assert.Contains(t, dot, `node1 -- network1 [taillabel="e0/0"];`, "DOT edge does not match expected value")
+
+	mermaid := graph.Mermaid()
+	assert.Contains(t, mermaid, `network1(("LAN"))`, "Mermaid network does not match expected value")
+	assert.Contains(t, mermaid, `node1 ---|"e0/0"| network1`, "Mermaid link does not match expected value")
+
+	data := graph.DiagramData()
+	if assert.Len(t, data.Nodes, 2, "Number of diagram nodes does not match expected value") {
+		assert.Equal(t, 100, data.Nodes[0].X, "Diagram node position does not match expected value")
+	}
+	if assert.Len(t, data.Links, 1, "Number of diagram links does not match expected value") {
+		assert.Equal(t, "e0/0", data.Links[0].SourceInterface, "Diagram link interface does not match expected value")
+	}
+
+	_, err = graph.Render(DiagramFormat("svg"))
+	assert.Error(t, err, "Invalid diagram format was accepted")
+}
+
+/*
+TestContainerlab covers:
+	- ParseContainerlabTopology
+	- ExportContainerlabTopology
+	- Marshal
+*/
+func TestContainerlab(t *testing.T) {
+	clabTopology, err := ParseContainerlabTopology([]byte(`
+name: srlceos
+topology:
+  defaults:
+    kind: ceos
+  nodes:
+    ceos1:
+      labels:
+        graph-posX: "10"
+        graph-posY: "20"
+    srl1:
+      kind: nokia_srlinux
+  links:
+    - endpoints: ["ceos1:eth1", "srl1:e1-1"]
+`))
+	if !assert.NoError(t, err, "Error during ParseContainerlabTopology operation") {
+		return
+	}
+	assert.Equal(t, "srlceos", clabTopology.Name, "Topology name does not match expected value")
+	assert.Equal(t, "ceos", clabTopology.NodeKind("ceos1"), "Default kind was not applied")
+	assert.Equal(t, "nokia_srlinux", clabTopology.NodeKind("srl1"), "Node kind does not match expected value")
+	assert.Len(t, clabTopology.Topology.Links, 1, "Number of links does not match expected value")
+
+	_, err = ParseContainerlabTopology([]byte(`topology: {}`))
+	assert.Error(t, err, "Topology without name was accepted")
+
+	nodes := Nodes{
+		"1": NodeWithID{ID: 1, Node: Node{Name: "sw 1", Template: "veos", Left: 10, Top: 20}},
+		"2": NodeWithID{ID: 2, Node: Node{Name: "sw2", Template: "veos"}},
+		"3": NodeWithID{ID: 3, Node: Node{Name: "fw", Template: "paloalto"}},
+	}
+	networks := Networks{"1": NetworkWithID{ID: 1, Network: Network{Name: "p2p"}}}
+	topology := TopologyPoints{
+		{Type: "ethernet", Source: "node1", SourceInterfaceID: 1, Destination: "network1", NetworkID: 1},
+		{Type: "ethernet", Source: "node2", SourceInterfaceID: 2, Destination: "network1", NetworkID: 1},
+	}
+	graph, err := NewGraph(nodes, networks, topology)
+	if !assert.NoError(t, err, "Error during NewGraph operation") {
+		return
+	}
+
+	var report ContainerlabReport
+	exported := ExportContainerlabTopology("export", graph, DefaultKindMappings(), &report)
+	assert.Len(t, exported.Topology.Nodes, 2, "Number of exported nodes does not match expected value")
+	assert.Equal(t, "arista_veos", exported.Topology.Nodes["sw-1"].Kind, "Exported node kind does not match expected value")
+	assert.Equal(t, "10", exported.Topology.Nodes["sw-1"].Labels["graph-posX"], "Exported node position does not match expected value")
+	if assert.Len(t, exported.Topology.Links, 1, "Number of exported links does not match expected value") {
+		assert.Equal(t, []string{"sw-1:eth2", "sw2:eth3"}, exported.Topology.Links[0].Endpoints, "Exported link does not match expected value")
+	}
+	for _, index := range []int{0, 1, 7} {
+		name := clabInterfaceName(index, 0)
+		imported, ok := clabInterfaceIndex(name, 0)
+		assert.True(t, ok && imported == index, "Interface "+name+" does not map back to index "+strconv.Itoa(index))
+	}
+	assert.Equal(t, "eth1", clabInterfaceName(0, 0), "First data interface is not eth1")
+	management, _ := clabInterfaceIndex("eth0", 0)
+	assert.True(t, management < 0, "Management interface eth0 was mapped to a data interface")
+	_, ok := clabInterfaceIndex("e1-1", 0)
+	assert.False(t, ok, "Non linux interface name was mapped by number")
+	assert.Equal(t, []string{"fw (template paloalto)"}, report.UnmappedNodes, "Unmapped nodes were not reported")
+
+	b, err := exported.Marshal()
+	if assert.NoError(t, err, "Error during Marshal operation") {
+		reparsed, err := ParseContainerlabTopology(b)
+		if assert.NoError(t, err, "Error while parsing exported topology") {
+			assert.Equal(t, exported.Topology.Links, reparsed.Topology.Links, "Exported topology does not survive a round trip")
+		}
+	}
+}
+
+/*
+TestImportContainerlabLinkFailure covers:
+	- ImportContainerlab
+*/
+func TestImportContainerlabLinkFailure(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []string
+	nodeID := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, r.Method+" "+path.Base(path.Dir(r.URL.Path))+"/"+path.Base(r.URL.Path))
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/clab.unl/nodes"):
+			nodeID++
+			_, _ = w.Write([]byte(`{"code":201,"status":"success","message":"","data":{"id":` + strconv.Itoa(nodeID) + `}}`))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/clab.unl/networks"):
+			_, _ = w.Write([]byte(`{"code":201,"status":"success","message":"","data":{"id":7}}`))
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/interfaces"):
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"ethernet":[{"name":"e0","network_id":0},{"name":"e1","network_id":0}],"serial":[]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":""}`))
+		}
+	}))
+	defer server.Close()
+
+	eveNgClient, err := NewEveNgClient(server.URL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	topology, err := ParseContainerlabTopology([]byte(`
+name: clab
+topology:
+  defaults:
+    kind: linux
+  nodes:
+    a: {}
+    b: {}
+  links:
+    - endpoints: ["a:eth1", "b:eth5"]
+`))
+	if !assert.NoError(t, err, "Error during ParseContainerlabTopology operation") {
+		return
+	}
+	report, err := eveNgClient.ImportContainerlab("/", topology, KindMappings{"linux": {Template: "linux", NodeType: "qemu"}})
+	if assert.NoError(t, err, "Error during ImportContainerlab operation") {
+		assert.Len(t, report.SkippedLinks, 1, "Link to a missing interface was not skipped")
+		assert.Empty(t, report.NetworkIDs, "Network of a skipped link is still reported")
+		assert.Contains(t, requests, "PUT 1/interfaces", "First end of the link was not connected")
+		assert.Equal(t, "DELETE networks/7", requests[len(requests)-1], "Network of a skipped link was not removed")
+	}
+}
+
+/*
+TestUNL covers:
+	- ParseUNL
+	- Marshal
+	- NodeConfig
+	- SetNodeConfig
+*/
+func TestUNL(t *testing.T) {
+	unl := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<lab name="UNLTesting" id="6a3e8f5e-5b1b-4c2d-9f0e-0a1b2c3d4e5f" version="1" scripttimeout="300" lock="0" countdown="60">
+  <description>A test laboratory</description>
+  <topology>
+    <nodes>
+      <node id="1" name="R1" type="iol" template="iol" image="i86bi-linux-l3.bin" ethernet="1" serial="1" delay="0" icon="Router.png" config="1" left="100" top="200">
+        <interface id="0" name="e0/0" type="ethernet" network_id="1"/>
+        <interface id="16" name="s1/0" type="serial" remote_id="2" remote_if="16"/>
+      </node>
+    </nodes>
+    <networks>
+      <network id="1" type="bridge" name="Net-R1" left="300" top="200" visibility="1"/>
+    </networks>
+  </topology>
+  <objects>
+    <configs>
+      <config id="1">aG9zdG5hbWUgUjE=</config>
+    </configs>
+  </objects>
+</lab>`
+
+	lab, err := ParseUNL([]byte(unl))
+	if !assert.NoError(t, err, "Error during ParseUNL operation") {
+		return
+	}
+	assert.Equal(t, "UNLTesting", lab.Name, "Lab name does not match expected value")
+	if assert.Len(t, lab.Topology.Nodes, 1, "Number of nodes does not match expected value") && assert.Len(t, lab.Topology.Nodes[0].Interfaces, 2, "Number of interfaces does not match expected value") {
+		assert.Equal(t, 2, lab.Topology.Nodes[0].Interfaces[1].RemoteID, "Serial interface remote id does not match expected value")
+	}
+	assert.Len(t, lab.Topology.Networks, 1, "Number of networks does not match expected value")
+
+	config, ok, err := lab.NodeConfig(1)
+	if assert.NoError(t, err, "Error during NodeConfig operation") && assert.True(t, ok, "Node config not found") {
+		assert.Equal(t, "hostname R1", config, "Node config does not match expected value")
+	}
+	lab.SetNodeConfig(2, "hostname R2")
+
+	b, err := lab.Marshal()
+	if assert.NoError(t, err, "Error during Marshal operation") {
+		assert.Contains(t, string(b), `countdown="60"`, "Unknown attribute was lost")
+		reparsed, err := ParseUNL(b)
+		if assert.NoError(t, err, "Error while parsing marshaled lab") {
+			assert.Equal(t, lab.Topology, reparsed.Topology, "Topology does not survive a round trip")
+			config, _, _ := reparsed.NodeConfig(2)
+			assert.Equal(t, "hostname R2", config, "Node config does not survive a round trip")
+		}
+	}
+}
+
+/*
+TestEveNgClient_LabFiles covers:
+	- ExportLabs
+	- ImportLabs
+	- DownloadLabFile
+	- UploadLabFile
+*/
+func TestEveNgClient_LabFiles(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labFolder := RootFolder
+	labName := "LabFileTesting"
+	labPath := LabPath(labName + ".unl")
+	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	if !assert.NoError(t, err, "Error during AddLab operation") {
+		return
+	}
+	defer func() {
+		_ = eveNgClient.RemoveLab(labPath)
+	}()
+
+	labFile, err := eveNgClient.DownloadLabFile(labPath)
+	if !assert.NoError(t, err, "Error during DownloadLabFile operation") {
+		return
+	}
+	lab, err := ParseUNL(labFile)
+	if assert.NoError(t, err, "Error during ParseUNL operation") {
+		assert.Equal(t, labName, lab.Name, "Lab name does not match expected value")
+	}
+
+	err = eveNgClient.RemoveLab(labPath)
+	if !assert.NoError(t, err, "Error during RemoveLab operation") {
+		return
+	}
+	err = eveNgClient.UploadLabFile(labFolder, labPath.File(), labFile)
+	if assert.NoError(t, err, "Error during UploadLabFile operation") {
+		uploadedLab, err := eveNgClient.GetLab(labPath)
+		if assert.NoError(t, err, "Error during GetLab operation") {
+			assert.Equal(t, lab.ID, uploadedLab.ID, "Uploaded lab id does not match expected value")
+		}
+	}
+}
+
+/*
+TestEveNgClient_ImportGNS3ProjectDryRun covers:
+	- ParseGNS3Project
+	- ImportGNS3Project in dry run mode
+*/
+func TestEveNgClient_ImportGNS3ProjectDryRun(t *testing.T) {
+	project, err := ParseGNS3Project([]byte(`{
+	"name": "gns3testing",
+	"topology": {
+		"nodes": [
+			{"node_id": "a", "name": "R1", "node_type": "dynamips", "x": -100, "y": -50, "properties": {"image": "/images/c7200-adventerprisek9-mz.124-24.T5.image"},
+				"ports": [{"name": "FastEthernet0/0", "adapter_number": 0, "port_number": 0, "link_type": "ethernet"}]},
+			{"node_id": "b", "name": "SW1", "node_type": "ethernet_switch", "x": 0, "y": 0,
+				"ports": [{"name": "Ethernet0", "adapter_number": 0, "port_number": 0, "link_type": "ethernet"}]},
+			{"node_id": "c", "name": "PC1", "node_type": "vpcs", "x": 100, "y": 50,
+				"ports": [{"name": "Ethernet0", "adapter_number": 0, "port_number": 0, "link_type": "ethernet"}]}
+		],
+		"links": [
+			{"link_id": "l1", "nodes": [{"node_id": "a", "adapter_number": 0, "port_number": 0}, {"node_id": "b", "adapter_number": 0, "port_number": 0}]},
+			{"link_id": "l2", "nodes": [{"node_id": "c", "adapter_number": 0, "port_number": 0}, {"node_id": "b", "adapter_number": 0, "port_number": 1}]}
+		],
+		"drawings": [{"drawing_id": "d1", "svg": "<svg></svg>"}]
+	}
+}`))
+	if !assert.NoError(t, err, "Error during ParseGNS3Project operation") {
+		return
+	}
+
+	eveNgClient, err := NewEveNgClient("http://localhost/")
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	mappings := KindMappings{"c7200-adventerprisek9-mz.124-24.T5.image": {Template: "c7200", NodeType: "dynamips", Image: "c7200-adventerprisek9-mz.124-24.T5.image"}}
+	report, err := eveNgClient.ImportGNS3Project("", project, mappings, true)
+	if assert.NoError(t, err, "Error during ImportGNS3Project operation") {
+		assert.Equal(t, LabPath("/gns3testing.unl"), report.LabPath, "Lab path does not match expected value")
+		assert.Contains(t, report.NodeIDs, "R1", "Mapped node was not planned")
+		assert.Contains(t, report.NetworkIDs, "SW1", "Switch was not planned as network")
+		assert.Contains(t, report.Actions, "connect R1:FastEthernet0/0 to network 1", "Link to switch was not planned")
+		if assert.Len(t, report.UnmappedNodes, 1, "Number of unmapped nodes does not match expected value") {
+			assert.Contains(t, report.UnmappedNodes[0], "PC1", "Unmapped node was not reported")
+		}
+		assert.Len(t, report.SkippedLinks, 1, "Link to unmapped node was not skipped")
+		assert.Len(t, report.Warnings, 1, "Drawings were not reported")
+	}
+
+	project, err = ParseGNS3Project([]byte(`{
+	"name": "frameRelay",
+	"topology": {
+		"nodes": [
+			{"node_id": "a", "name": "R1", "node_type": "dynamips", "properties": {"image": "/images/c7200-adventerprisek9-mz.124-24.T5.image"},
+				"ports": [{"name": "Serial1/0", "adapter_number": 1, "port_number": 0, "link_type": "serial"}]},
+			{"node_id": "b", "name": "FR1", "node_type": "frame_relay_switch",
+				"ports": [{"name": "1", "adapter_number": 0, "port_number": 1, "link_type": "serial"}]}
+		],
+		"links": [
+			{"link_id": "l1", "nodes": [{"node_id": "a", "adapter_number": 1, "port_number": 0}, {"node_id": "b", "adapter_number": 0, "port_number": 1}]}
+		]
+	}
+}`))
+	if !assert.NoError(t, err, "Error during ParseGNS3Project operation") {
+		return
+	}
+	report, err = eveNgClient.ImportGNS3Project("", project, mappings, true)
+	if assert.NoError(t, err, "Error during ImportGNS3Project operation") {
+		assert.Contains(t, report.Actions, "connect serial R1:Serial1/0 to network 1", "Serial link to frame relay switch was not planned")
+		assert.Empty(t, report.SkippedLinks, "Serial link to frame relay switch was skipped")
+	}
+
+	node := GNS3Node{Name: "IOU1", Ports: []GNS3Port{
+		{AdapterNumber: 0, PortNumber: 0}, {AdapterNumber: 0, PortNumber: 1},
+		{AdapterNumber: 1, PortNumber: 0, LinkType: "serial"},
+		{AdapterNumber: 2, PortNumber: 0}, {AdapterNumber: 2, PortNumber: 1},
+	}}
+	for _, test := range []struct {
+		port  GNS3Port
+		index int
+	}{
+		{GNS3Port{AdapterNumber: 0, PortNumber: 0}, 0},
+		{GNS3Port{AdapterNumber: 0, PortNumber: 1}, 1},
+		{GNS3Port{AdapterNumber: 2, PortNumber: 0}, 2},
+		{GNS3Port{AdapterNumber: 2, PortNumber: 1}, 3},
+	} {
+		index, err := gns3PortIndex(node, test.port)
+		if assert.NoError(t, err, "Error during gns3PortIndex operation") {
+			assert.Equal(t, test.index, index, "Index of adapter "+strconv.Itoa(test.port.AdapterNumber)+" port "+strconv.Itoa(test.port.PortNumber)+" does not match expected value")
+		}
+	}
+	index, err := gns3PortIndex(GNS3Node{}, GNS3Port{AdapterNumber: 3})
+	if assert.NoError(t, err, "Error during gns3PortIndex operation without port list") {
+		assert.Equal(t, 3, index, "Adapter number was not used without port list")
+	}
+	_, err = gns3PortIndex(GNS3Node{}, GNS3Port{AdapterNumber: 3, PortNumber: 1})
+	assert.Error(t, err, "Unlisted port was mapped to its adapter")
+}
+
+/*
+TestPictures covers:
+	- Pictures.UnmarshalJSON
+	- BuildImageMap
+*/
+func TestPictures(t *testing.T) {
+	var pictures Pictures
+	err := json.Unmarshal([]byte(`{"2":{"id":2,"name":"Rack","type":"image/png"},"1":{"id":1,"name":"Site","type":"image/jpeg"}}`), &pictures)
+	if assert.NoError(t, err, "Error during unmarshal of pictures") && assert.Len(t, pictures, 2, "Number of pictures does not match expected value") {
+		assert.Equal(t, "Site", pictures[0].Name, "Pictures are not sorted by id")
+	}
+	err = json.Unmarshal([]byte(`{"3":{"name":"Floor","type":"image/png"}}`), &pictures)
+	if assert.NoError(t, err, "Error during unmarshal of pictures without id") && assert.Len(t, pictures, 1, "Number of pictures does not match expected value") {
+		assert.Equal(t, 3, pictures[0].ID, "Picture id was not taken from the key")
+	}
+
+	imageMap := BuildImageMap([]ImageMapArea{
+		{Shape: ImageMapShapeRect, Coords: []int{10, 20, 110, 70}, NodeID: 1},
+		{Shape: ImageMapShapeCircle, Coords: []int{200, 200, 30}, NodeID: 2, Alt: "Core <1>"},
+	})
+	assert.Equal(t, "<area shape='rect' alt='Node 1' coords='10,20,110,70' href='telnet://{{IP}}:{{NODE1}}'>\n<area shape='circle' alt='Core &lt;1&gt;' coords='200,200,30' href='telnet://{{IP}}:{{NODE2}}'>", imageMap, "Image map does not match expected value")
+}
+
+/*
+TestEveNgClient_Pictures covers:
+	- AddPicture
+	- GetPictures
+	- GetPicture
+	- DownloadPictureData
+	- EditPicture
+	- RemovePicture
+*/
+func TestEveNgClient_Pictures(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labFolder := RootFolder
+	labName := "PictureTesting"
+	labPath := LabPath(labName + ".unl")
+	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	defer func() {
+		_ = eveNgClient.RemoveLab(labPath)
+	}()
+
+	var imageData bytes.Buffer
+	err = png.Encode(&imageData, image.NewRGBA(image.Rect(0, 0, 64, 32)))
+	if !assert.NoError(t, err, "Error while encoding test image") {
+		return
+	}
+
+	_, err = eveNgClient.AddPicture(labPath, "TestPicture", "", []byte("not an image"))
+	assert.Error(t, err, "AddPicture accepted data which is not an image")
+
+	_, err = eveNgClient.AddPicture(labPath, "TestPicture", BuildImageMap([]ImageMapArea{{Shape: ImageMapShapeRect, Coords: []int{0, 0, 10, 10}, NodeID: 1}}), imageData.Bytes())
+	if !assert.NoError(t, err, "Error during AddPicture operation") {
+		return
+	}
+	pictures, err := eveNgClient.GetPictures(labPath)
+	if !assert.NoError(t, err, "Error during GetPictures operation") || !assert.Len(t, pictures, 1, "Number of pictures does not match expected value") {
+		return
+	}
+	pictureID := pictures[0].ID
+
+	picture, err := eveNgClient.GetPicture(labPath, pictureID)
+	if assert.NoError(t, err, "Error during GetPicture operation") {
+		assert.Equal(t, "TestPicture", picture.Name, "Picture name does not match expected value")
+		assert.Equal(t, 64, picture.Width, "Picture width does not match expected value")
+		assert.Contains(t, picture.Map, "{{NODE1}}", "Picture map does not match expected value")
+	}
+
+	data, err := eveNgClient.DownloadPictureData(labPath, pictureID)
+	if assert.NoError(t, err, "Error during DownloadPictureData operation") {
+		assert.NotEmpty(t, data, "Picture data is empty")
+	}
+
+	err = eveNgClient.EditPicture(labPath, pictureID, "RenamedPicture", "")
+	if assert.NoError(t, err, "Error during EditPicture operation") {
+		picture, err := eveNgClient.GetPicture(labPath, pictureID)
+		if assert.NoError(t, err, "Error during GetPicture operation") {
+			assert.Equal(t, "RenamedPicture", picture.Name, "Picture was not renamed")
+		}
+	}
+
+	err = eveNgClient.RemovePicture(labPath, pictureID)
+	if assert.NoError(t, err, "Error during RemovePicture operation") {
+		pictures, err := eveNgClient.GetPictures(labPath)
+		if assert.NoError(t, err, "Error during GetPictures operation") {
+			assert.Empty(t, pictures, "Picture was not removed")
+		}
+	}
+}
+
+/*
+TestTextShape covers:
+	- TextObjects.UnmarshalJSON
+	- TextShape.HTML
+*/
+func TestTextShape(t *testing.T) {
+	var textObjects TextObjects
+	err := json.Unmarshal([]byte(`{"3":{"name":"Title","type":"text","data":"<div></div>"}}`), &textObjects)
+	if assert.NoError(t, err, "Error during unmarshal of text objects") && assert.Len(t, textObjects, 1, "Number of text objects does not match expected value") {
+		assert.Equal(t, 3, textObjects[0].ID, "Text object id was not taken from its key")
+	}
+
+	label := TextShape{Kind: TextShapeText, Text: "Site <A>", Left: 10, Top: 20, FontSize: 24}.HTML(3)
+	assert.Contains(t, label, `id="customText3"`, "Label html does not contain its id")
+	assert.Contains(t, label, "left: 10px; top: 20px;", "Label html does not contain its position")
+	assert.Contains(t, label, "font-size: 24px;", "Label html does not contain its font size")
+	assert.Contains(t, label, "Site &lt;A&gt;", "Label text was not escaped")
+
+	boundary := TextShape{Kind: TextShapeSquare, Width: 400, Height: 300, BorderColor: "#ff0000"}.HTML(4)
+	assert.Contains(t, boundary, `<rect width="400" height="300" fill="#ffffff" fill-opacity="0" stroke="#ff0000" stroke-width="5"></rect>`, "Rectangle html does not match expected value")
+
+	circle := TextShape{Kind: TextShapeCircle, Width: 100, Height: 100}.HTML(5)
+	assert.Contains(t, circle, `<ellipse cx="50" cy="50" rx="48" ry="48"`, "Circle html does not match expected value")
+}
+
+/*
+TestEveNgClient_TextObjects covers:
+	- AddTextObject
+	- AddTextShape
+	- GetTextObjects
+	- GetTextObject
+	- EditTextObject
+	- RemoveTextObject
+*/
+func TestEveNgClient_TextObjects(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labFolder := RootFolder
+	labName := "TextObjectTesting"
+	labPath := LabPath(labName + ".unl")
+	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	defer func() {
+		_ = eveNgClient.RemoveLab(labPath)
+	}()
+
+	_, err = eveNgClient.AddTextObject(labPath, "Title", "text", TextShape{Text: "Title"}.HTML(1))
+	if !assert.NoError(t, err, "Error during AddTextObject operation") {
+		return
+	}
+	boundaryID, err := eveNgClient.AddTextShape(labPath, "Boundary", TextShape{Kind: TextShapeSquare, Left: 50, Top: 50, Width: 500, Height: 300})
+	if !assert.NoError(t, err, "Error during AddTextShape operation") {
+		return
+	}
+
+	textObjects, err := eveNgClient.GetTextObjects(labPath)
+	if assert.NoError(t, err, "Error during GetTextObjects operation") {
+		assert.Len(t, textObjects, 2, "Number of text objects does not match expected value")
+	}
+
+	boundary, err := eveNgClient.GetTextObject(labPath, boundaryID)
+	if assert.NoError(t, err, "Error during GetTextObject operation") {
+		assert.Equal(t, "Boundary", boundary.Name, "Text object name does not match expected value")
+		assert.Equal(t, "square", boundary.Type, "Text object type does not match expected value")
+		assert.Contains(t, boundary.Data, `id="customShape`+strconv.Itoa(boundaryID)+`"`, "Text object html does not contain its id")
+	}
+
+	err = eveNgClient.EditTextObject(labPath, boundaryID, "Site A", TextShape{Kind: TextShapeSquare, Width: 600, Height: 300}.HTML(boundaryID))
+	if assert.NoError(t, err, "Error during EditTextObject operation") {
+		boundary, err := eveNgClient.GetTextObject(labPath, boundaryID)
+		if assert.NoError(t, err, "Error during GetTextObject operation") {
+			assert.Contains(t, boundary.Data, `width="600"`, "Text object was not edited")
+		}
+	}
+
+	err = eveNgClient.RemoveTextObject(labPath, boundaryID)
+	if assert.NoError(t, err, "Error during RemoveTextObject operation") {
+		textObjects, err := eveNgClient.GetTextObjects(labPath)
+		if assert.NoError(t, err, "Error during GetTextObjects operation") {
+			assert.Len(t, textObjects, 1, "Text object was not removed")
+		}
+	}
+}
+
+/*
+TestImpairment covers:
+	- Impairment.Validate
+	- findLinkState
+*/
+func TestImpairment(t *testing.T) {
+	assert.NoError(t, Impairment{Delay: 200, Jitter: 20, Loss: 5, Bandwidth: 2048}.Validate(), "Valid impairment was rejected")
+	assert.Error(t, Impairment{Loss: 101}.Validate(), "Loss above 100 percent was accepted")
+	assert.Error(t, Impairment{Delay: -1}.Validate(), "Negative delay was accepted")
+
+	topology := TopologyPoints{
+		{Type: "serial", Source: "node1", SourceInterfaceID: 16, SourceDelay: 100, Destination: "node2", DestinationInterfaceID: "32", DestinationLoss: 10, DestinationSuspend: 1},
+	}
+	state, err := findLinkState(topology, 1, 16)
+	if assert.NoError(t, err, "Error during findLinkState operation") {
+		assert.Equal(t, LinkState{Impairment: Impairment{Delay: 100}}, state, "Source link state does not match expected value")
+	}
+	state, err = findLinkState(topology, 2, 32)
+	if assert.NoError(t, err, "Error during findLinkState operation") {
+		assert.Equal(t, LinkState{Impairment: Impairment{Loss: 10}, Suspended: true}, state, "Destination link state does not match expected value")
+	}
+	_, err = findLinkState(topology, 1, 0)
+	assert.Error(t, err, "Link state of unconnected interface was found")
+}
+
+/*
+TestScenario covers:
+	- ParseScenario
+	- Scenario.Validate
+*/
+func TestScenario(t *testing.T) {
+	scenario, err := ParseScenario([]byte(`
+name: wan-failover
+lab: /Demo/Core.unl
+steps:
+  - action: suspend-link
+    node: R1
+    interface: e0/1
+  - action: impair-link
+    after: 5s
+    node: R2
+    interface: Gi0/0
+    delay: 200
+    loss: 1
+  - action: wait
+    duration: 1m30s
+  - action: restart-node
+    node: "3"
+`))
+	if assert.NoError(t, err, "Error during ParseScenario operation") && assert.Len(t, scenario.Steps, 4, "Number of steps does not match expected value") {
+		assert.Equal(t, 5*time.Second, scenario.Steps[1].After, "Step delay does not match expected value")
+		assert.Equal(t, 90*time.Second, scenario.Steps[2].Duration, "Wait duration does not match expected value")
+		assert.Equal(t, "impair-link R2:Gi0/0 delay=200ms jitter=0ms loss=1% bandwidth=0kbit/s", scenario.Steps[1].String(), "Step description does not match expected value")
+	}
+
+	_, err = ParseScenario([]byte("lab: Core.unl\nsteps:\n  - action: explode\n    node: R1\n"))
+	assert.Error(t, err, "Unknown action was accepted")
+	_, err = ParseScenario([]byte("lab: Core.unl\nsteps:\n  - action: impair-link\n    node: R1\n    interface: e0/0\n    loss: 120\n"))
+	assert.Error(t, err, "Invalid impairment was accepted")
+	_, err = ParseScenario([]byte("lab: Core.unl\nsteps:\n  - action: wait\n    duraton: 5s\n"))
+	assert.Error(t, err, "Unknown field was accepted")
+}
+
+/*
+TestEveNgClient_RunScenarioCanceled covers:
+	- RunScenario rollback on cancellation
+*/
+func TestEveNgClient_RunScenarioCanceled(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labFolder := RootFolder
+	labName := "ScenarioTesting"
+	labPath := LabPath(labName + ".unl")
+	err = eveNgClient.AddLab(labFolder, labName, "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	defer func() {
+		_ = eveNgClient.RemoveLab(labPath)
+	}()
+
+	nodeID, err := eveNgClient.AddNode(labPath, "qemu", "asav", "0", 0, "ASA.png", "asav-952-204", "ASAv", 404, 227, 2048, "telnet", 1, "undefined", 8, "", "", "", "", 1)
+	if !assert.NoError(t, err, "Error during AddNode operation") {
+		return
+	}
+	err = eveNgClient.StartNode(labPath, nodeID)
+	if !assert.NoError(t, err, "Error during StartNode operation") {
+		return
+	}
+	defer func() {
+		_ = eveNgClient.StopNodes(labPath)
+	}()
+
+	scenario := Scenario{
+		Name: "canceled",
+		Lab:  labPath,
+		Steps: []ScenarioStep{
+			{Action: ScenarioActionStopNode, Node: "ASAv"},
+			{Action: ScenarioActionWait, Duration: time.Hour},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = eveNgClient.RunScenario(ctx, scenario, nil)
+	if assert.Error(t, err, "Canceled scenario did not return an error") {
+		assert.Equal(t, context.DeadlineExceeded, errors.Cause(err), "Scenario error does not match expected value")
+	}
+
+	node, err := eveNgClient.GetNode(labPath, nodeID)
+	if assert.NoError(t, err, "Error during GetNode operation") {
+		assert.Equal(t, NodeStatusRunning, node.Status, "Stopped node was not restarted during rollback")
+	}
+}
+
+/*
+TestRunScenarioCancelInFlight covers:
+	- RunScenario cancellation of a running step
+	- RunScenario rollback with a fresh context
+*/
+func TestRunScenarioCancelInFlight(t *testing.T) {
+	var started int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path.Clean(r.URL.Path) {
+		case "/api/labs/Scenario.unl/nodes":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"1":{"id":1,"name":"R1","status":2},"2":{"id":2,"name":"R2","status":2}}}`))
+		case "/api/labs/Scenario.unl/nodes/1":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"id":1,"name":"R1","status":2}}`))
+		case "/api/labs/Scenario.unl/nodes/2":
+			// hangs until the client gives up
+			<-r.Context().Done()
+		case "/api/labs/Scenario.unl/nodes/1/start":
+			atomic.AddInt32(&started, 1)
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":""}`))
+		default:
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":""}`))
+		}
+	}))
+	defer server.Close()
+
+	eveNgClient, err := NewEveNgClient(server.URL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	scenario := Scenario{
+		Name: "cancel",
+		Lab:  "/Scenario.unl",
+		Steps: []ScenarioStep{
+			{Action: ScenarioActionStopNode, Node: "R1"},
+			{Action: ScenarioActionStopNode, Node: "R2"},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = eveNgClient.RunScenario(ctx, scenario, nil)
+	if assert.Error(t, err, "Canceled scenario did not fail") {
+		scenarioErr, ok := err.(*ScenarioError)
+		if assert.True(t, ok, "Error is no ScenarioError") {
+			assert.Equal(t, 1, scenarioErr.Step, "Failed step does not match expected value")
+			assert.Empty(t, scenarioErr.RollbackErrors, "Rollback failed after cancellation")
+		}
+	}
+	assert.True(t, time.Since(start) < 5*time.Second, "Running step was not canceled")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started), "Stopped node was not started again by the rollback")
+}
+
+/*
+TestEveNgClient_WalkFolders covers:
+	- WalkFolders
+	- WalkFoldersWithOptions
+	- GetFolderTree
+	- FindLabs
+*/
+func TestEveNgClient_WalkFolders(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	rootFolder := FolderPath("/WalkTesting")
+	subFolder := rootFolder.Join("Sub")
+	err = eveNgClient.AddFolder(RootFolder, rootFolder.Base())
+	if !assert.NoError(t, err, "Error during AddFolder operation") {
+		return
+	}
+	defer func() {
+		_ = eveNgClient.RemoveLab(subFolder.Lab("WalkLab"))
+		_ = eveNgClient.RemoveFolder(subFolder)
+		_ = eveNgClient.RemoveFolder(rootFolder)
+	}()
+	err = eveNgClient.AddFolder(rootFolder, subFolder.Base())
+	if !assert.NoError(t, err, "Error during AddFolder operation") {
+		return
+	}
+	err = eveNgClient.AddLab(subFolder, "WalkLab", "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	if !assert.NoError(t, err, "Error during AddLab operation") {
+		return
+	}
+
+	var visited []string
+	err = eveNgClient.WalkFolders(context.Background(), rootFolder, func(entry WalkEntry) error {
+		visited = append(visited, entry.Path)
+		return nil
+	})
+	if assert.NoError(t, err, "Error during WalkFolders operation") {
+		assert.ElementsMatch(t, []string{"/WalkTesting", "/WalkTesting/Sub", "/WalkTesting/Sub/WalkLab.unl"}, visited, "Visited entries do not match expected value")
+	}
+
+	visited = nil
+	err = eveNgClient.WalkFoldersWithOptions(context.Background(), rootFolder, WalkOptions{MaxDepth: 1, Concurrency: 1}, func(entry WalkEntry) error {
+		visited = append(visited, entry.Path)
+		return nil
+	})
+	if assert.NoError(t, err, "Error during WalkFoldersWithOptions operation") {
+		assert.ElementsMatch(t, []string{"/WalkTesting", "/WalkTesting/Sub"}, visited, "Depth limit was not respected")
+	}
+
+	tree, err := eveNgClient.GetFolderTree(context.Background(), rootFolder, 0)
+	if assert.NoError(t, err, "Error during GetFolderTree operation") && assert.Len(t, tree.Folders, 1, "Number of subfolders does not match expected value") {
+		assert.Equal(t, "Sub", tree.Folders[0].Name, "Subfolder name does not match expected value")
+		assert.Equal(t, []LabPath{"/WalkTesting/Sub/WalkLab.unl"}, tree.LabPaths(), "Lab paths do not match expected value")
+	}
+
+	labs, err := eveNgClient.FindLabs("/", "Walk*.unl")
+	if assert.NoError(t, err, "Error during FindLabs operation") && assert.Len(t, labs, 1, "Number of found labs does not match expected value") {
+		assert.Equal(t, "/WalkTesting/Sub/WalkLab.unl", labs[0].Path, "Lab path does not match expected value")
+	}
+	_, err = eveNgClient.FindLabs("/", "[")
+	assert.Error(t, err, "Invalid pattern was accepted")
+}
+
+/*
+TestEveNgClient_EnsureFolder covers:
+	- EnsureFolder
+	- RemoveFolderRecursive
+*/
+func TestEveNgClient_EnsureFolder(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	folderPath := FolderPath("/EnsureTesting/A/B")
+	err = eveNgClient.EnsureFolder(folderPath)
+	if !assert.NoError(t, err, "Error during EnsureFolder operation") {
+		return
+	}
+	err = eveNgClient.EnsureFolder(folderPath)
+	assert.NoError(t, err, "Error during repeated EnsureFolder operation")
+
+	err = eveNgClient.AddLab(folderPath, "EnsureLab", "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	if !assert.NoError(t, err, "Error during AddLab operation") {
+		return
+	}
+
+	report, err := eveNgClient.RemoveFolderRecursive("/EnsureTesting", RemoveFolderOptions{DryRun: true})
+	if assert.NoError(t, err, "Error during dry run RemoveFolderRecursive operation") {
+		assert.Equal(t, []LabPath{"/EnsureTesting/A/B/EnsureLab.unl"}, report.Labs, "Labs to remove do not match expected value")
+		assert.Equal(t, []FolderPath{"/EnsureTesting/A/B", "/EnsureTesting/A", "/EnsureTesting"}, report.Folders, "Folders to remove do not match expected value")
+	}
+	tree, err := eveNgClient.GetFolderTree(context.Background(), "/EnsureTesting", 0)
+	if assert.NoError(t, err, "Error during GetFolderTree operation") {
+		assert.Len(t, tree.LabPaths(), 1, "Dry run removed labs")
+	}
+
+	report, err = eveNgClient.RemoveFolderRecursive("/EnsureTesting", RemoveFolderOptions{})
+	if assert.NoError(t, err, "Error during RemoveFolderRecursive operation") {
+		assert.Len(t, report.Folders, 3, "Number of removed folders does not match expected value")
+	}
+	_, err = eveNgClient.RemoveFolderRecursive("/", RemoveFolderOptions{DryRun: true})
+	assert.Error(t, err, "Removing the root folder was accepted")
+}
+
+/*
+TestEveNgClient_EnsureLab covers:
+	- LabExists
+	- EnsureLab
+	- FindLabByID
+*/
+func TestEveNgClient_EnsureLab(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labPath := LabPath("/EnsureLabTesting/EnsureLab.unl")
+	defer func() {
+		_, _ = eveNgClient.RemoveFolderRecursive("/EnsureLabTesting", RemoveFolderOptions{})
+	}()
+
+	exists, err := eveNgClient.LabExists(labPath)
+	if assert.NoError(t, err, "Error during LabExists operation") {
+		assert.False(t, exists, "Lab exists before it was created")
+	}
+
+	spec := LabSpec{Author: "admin", Description: "A test laboratory"}
+	changed, err := eveNgClient.EnsureLab(labPath, spec)
+	if assert.NoError(t, err, "Error during EnsureLab operation") {
+		assert.True(t, changed, "Created lab was not reported as changed")
+	}
+	changed, err = eveNgClient.EnsureLab(labPath, spec)
+	if assert.NoError(t, err, "Error during repeated EnsureLab operation") {
+		assert.False(t, changed, "Unchanged lab was reported as changed")
+	}
+	spec.Description = "An edited test laboratory"
+	changed, err = eveNgClient.EnsureLab(labPath, spec)
+	if assert.NoError(t, err, "Error during EnsureLab operation") {
+		assert.True(t, changed, "Edited lab was not reported as changed")
+	}
+
+	exists, err = eveNgClient.LabExists(labPath)
+	if assert.NoError(t, err, "Error during LabExists operation") {
+		assert.True(t, exists, "Created lab does not exist")
+	}
+
+	lab, err := eveNgClient.GetLab(labPath)
+	if !assert.NoError(t, err, "Error during GetLab operation") {
+		return
+	}
+	assert.Equal(t, spec.Description, lab.Description, "Lab description does not match expected value")
+	foundPath, foundLab, err := eveNgClient.FindLabByID(lab.ID)
+	if assert.NoError(t, err, "Error during FindLabByID operation") {
+		assert.Equal(t, labPath, foundPath, "Lab path does not match expected value")
+		assert.Equal(t, lab.Name, foundLab.Name, "Lab name does not match expected value")
+	}
+	_, _, err = eveNgClient.FindLabByID("00000000-0000-0000-0000-000000000000")
+	_, ok := errors.Cause(err).(*LabNotFoundError)
+	assert.True(t, ok, "Unknown lab id did not return a LabNotFoundError")
+}
+
+/*
+TestEnsureLabName covers:
+	- EnsureLab
+*/
+func TestEnsureLabName(t *testing.T) {
+	var requests []string
+	var edit map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + path.Clean(r.URL.Path) {
+		case "GET /api/folders":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"folders":[],"labs":[{"file":"Ensure.unl","path":"/Ensure.unl"}]}}`))
+		case "GET /api/labs/Ensure.unl":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"id":"1","name":"Ensure","version":"1","author":"admin","description":"A test laboratory"}}`))
+		case "PUT /api/labs/Ensure.unl":
+			_ = json.NewDecoder(r.Body).Decode(&edit)
+			_, _ = w.Write([]byte(`{"code":201,"status":"success","message":"Lab has been saved (60023)."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"status":"fail","message":"Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	eveNgClient, err := NewEveNgClient(server.URL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	_, err = eveNgClient.EnsureLab("/Ensure.unl", LabSpec{Name: "Renamed"})
+	assert.Error(t, err, "Lab name other than the lab file name was accepted")
+	assert.Empty(t, requests, "Rejected spec sent requests")
+
+	changed, err := eveNgClient.EnsureLab("/Ensure.unl", LabSpec{Description: "An edited test laboratory"})
+	if assert.NoError(t, err, "Error during EnsureLab operation") {
+		assert.True(t, changed, "Edited lab was not reported as changed")
+		assert.Equal(t, "Ensure", edit["name"], "Edited lab was renamed")
+		assert.Equal(t, "An edited test laboratory", edit["description"], "Edited description does not match expected value")
+	}
+}
+
+/*
+TestLabPath covers:
+	- ParseLabPath
+	- ParseFolderPath
+	- LabPath and FolderPath helpers
+	- urlEscapePath
+*/
+func TestLabPath(t *testing.T) {
+	for _, raw := range []string{"/Folder/Lab.unl", "Folder/Lab.unl", "Folder/Lab", "//Folder/./Lab"} {
+		labPath, err := ParseLabPath(raw)
+		if assert.NoError(t, err, "Error during ParseLabPath operation") {
+			assert.Equal(t, LabPath("/Folder/Lab.unl"), labPath, "Normalized lab path does not match expected value")
+		}
+	}
+	labPath := LabPath("My Folder/My Lab")
+	assert.Equal(t, "/My Folder/My Lab.unl", labPath.String(), "Lab path does not match expected value")
+	assert.Equal(t, FolderPath("/My Folder"), labPath.Folder(), "Lab folder does not match expected value")
+	assert.Equal(t, "My Lab.unl", labPath.File(), "Lab file does not match expected value")
+	assert.Equal(t, "My Lab", labPath.Name(), "Lab name does not match expected value")
+	assert.Equal(t, "labs/My%20Folder/My%20Lab.unl/nodes", urlEscapePath("labs/"+labPath.api()+"/nodes"), "Escaped url path does not match expected value")
+
+	_, err := ParseLabPath("")
+	assert.Error(t, err, "Empty lab path was accepted")
+	_, err = ParseLabPath("Folder/../../Lab.unl")
+	assert.Error(t, err, "Lab path with parent folder reference was accepted")
+	_, err = ParseLabPath("Folder/\nLab.unl")
+	assert.Error(t, err, "Lab path with control character was accepted")
+
+	for _, raw := range []string{"", "/", "."} {
+		folderPath, err := ParseFolderPath(raw)
+		if assert.NoError(t, err, "Error during ParseFolderPath operation") {
+			assert.True(t, folderPath.IsRoot(), "Folder path is not the root folder")
+			assert.Empty(t, folderPath.Segments(), "Root folder has segments")
+		}
+	}
+	folderPath := RootFolder.Join("A", "B")
+	assert.Equal(t, FolderPath("/A/B"), folderPath, "Joined folder path does not match expected value")
+	assert.Equal(t, FolderPath("/A"), folderPath.Parent(), "Parent folder does not match expected value")
+	assert.Equal(t, "B", folderPath.Base(), "Folder name does not match expected value")
+	assert.Equal(t, []string{"A", "B"}, folderPath.Segments(), "Folder segments do not match expected value")
+	assert.Equal(t, LabPath("/A/B/Lab.unl"), folderPath.Lab("Lab"), "Lab path does not match expected value")
+	assert.Equal(t, RootFolder, RootFolder.Parent(), "Parent of the root folder does not match expected value")
+}
+
+/*
+TestCapacity covers:
+	- Nodes.Requirements
+	- LabSpec.Requirements
+	- ServerCapacity.Estimate
+*/
+func TestCapacity(t *testing.T) {
+	nodes := Nodes{
+		"1": NodeWithID{ID: 1, Node: Node{Name: "R1", RAM: 1024, CPU: 1, Status: NodeStatusStopped}},
+		"2": NodeWithID{ID: 2, Node: Node{Name: "R2", RAM: 2048, CPU: 2, Status: NodeStatusRunning}},
+		"3": NodeWithID{ID: 3, Node: Node{Name: "R3", RAM: 4096, CPU: 2, Status: NodeStatusStopped}},
+	}
+	assert.Equal(t, ResourceRequirements{Nodes: 2, RAM: 5120, CPU: 3}, nodes.Requirements(), "Requirements of nodes do not match expected value")
+
+	spec := LabSpec{Nodes: []NodeSpec{{Name: "leaf", RAM: 2048, CPU: 2, Count: 4}, {Name: "spine", RAM: 4096, CPU: 4}}}
+	assert.Equal(t, ResourceRequirements{Nodes: 5, RAM: 12288, CPU: 12}, spec.Requirements(), "Requirements of lab spec do not match expected value")
+
+	capacity := ServerCapacity{RAM: 16384, CPUs: 8}
+	estimate, err := capacity.Estimate(SystemStatus{Mem: 40, CPU: 95}, spec.Requirements())
+	if assert.NoError(t, err, "Error during Estimate operation") {
+		assert.Equal(t, 8192, estimate.AvailableRAM, "Available memory does not match expected value")
+		assert.False(t, estimate.CanStart, "Lab which exceeds the memory can be started")
+		assert.Len(t, estimate.Warnings, 2, "Number of warnings does not match expected value")
+	}
+
+	estimate, err = capacity.Estimate(SystemStatus{Mem: 10}, nodes.Requirements())
+	if assert.NoError(t, err, "Error during Estimate operation") {
+		assert.True(t, estimate.CanStart, "Lab which fits into memory can not be started")
+		assert.Empty(t, estimate.Warnings, "Unexpected warnings")
+	}
+
+	estimate, err = capacity.Estimate(SystemStatus{Mem: 95}, ResourceRequirements{})
+	if assert.NoError(t, err, "Error during Estimate operation") {
+		assert.Equal(t, 0, estimate.AvailableRAM, "Available memory above the limit is not zero")
+		assert.True(t, estimate.CanStart, "Nothing to start can not be started")
+	}
+
+	_, err = ServerCapacity{CPUs: 8}.Estimate(SystemStatus{Mem: 10}, nodes.Requirements())
+	assert.Error(t, err, "Capacity without ram was accepted")
+}
+
+/*
+TestEveNgClient_PreflightStartLab covers:
+	- EstimateLabCapacity
+	- PreflightStartLab
+*/
+func TestEveNgClient_PreflightStartLab(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labPath := LabPath("PreflightTesting.unl")
+	err = eveNgClient.AddLab(RootFolder, labPath.Name(), "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	if !assert.NoError(t, err, "Error during AddLab operation") {
+		return
+	}
+	defer func() {
+		_ = eveNgClient.StopNodes(labPath)
+		_ = eveNgClient.RemoveLab(labPath)
+	}()
+	_, err = eveNgClient.AddNode(labPath, "qemu", "asav", "0", 0, "ASA.png", "asav-952-204", "ASAv", 404, 227, 2048, "telnet", 1, "undefined", 8, "", "", "", "", 1)
+	if !assert.NoError(t, err, "Error during AddNode operation") {
+		return
+	}
+
+	estimate, err := eveNgClient.EstimateLabCapacity(labPath, ServerCapacity{RAM: 1 << 20})
+	if assert.NoError(t, err, "Error during EstimateLabCapacity operation") {
+		assert.Equal(t, 2048, estimate.Required.RAM, "Required memory does not match expected value")
+	}
+
+	_, err = eveNgClient.PreflightStartLab(labPath, ServerCapacity{RAM: 1024})
+	_, ok := errors.Cause(err).(*InsufficientCapacityError)
+	assert.True(t, ok, "Lab was started although the server has not enough memory")
+}
+
+/*
+TestEveNgClient_EditNodeCloneLab covers:
+	- EditNode
+	- CloneLab
+*/
+func TestEveNgClient_EditNodeCloneLab(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	labPath := LabPath("EditNodeTesting.unl")
+	clonePath := LabPath("EditNodeTestingClone.unl")
+	err = eveNgClient.AddLab(RootFolder, labPath.Name(), "1", "admin", "A test laboratory", "Test laboratory for unit and integration tests")
+	if !assert.NoError(t, err, "Error during AddLab operation") {
+		return
+	}
+	defer func() {
+		_ = eveNgClient.RemoveLab(labPath)
+		_ = eveNgClient.RemoveLab(clonePath)
+	}()
+	nodeID, err := eveNgClient.AddNode(labPath, "qemu", "asav", "0", 0, "ASA.png", "asav-952-204", "ASAv", 404, 227, 2048, "telnet", 1, "undefined", 8, "", "", "", "", 1)
+	if !assert.NoError(t, err, "Error during AddNode operation") {
+		return
+	}
+
+	node, err := eveNgClient.GetNode(labPath, nodeID)
+	if !assert.NoError(t, err, "Error during GetNode operation") {
+		return
+	}
+	node.Name = "EditedASAv"
+	node.RAM = 4096
+	err = eveNgClient.EditNode(labPath, nodeID, node)
+	if !assert.NoError(t, err, "Error during EditNode operation") {
+		return
+	}
+	node, err = eveNgClient.GetNode(labPath, nodeID)
+	if assert.NoError(t, err, "Error during GetNode operation") {
+		assert.Equal(t, "EditedASAv", node.Name, "Node name does not match expected value")
+		assert.Equal(t, 4096, node.RAM, "Node ram does not match expected value")
+	}
+
+	err = eveNgClient.CloneLab(labPath, clonePath)
+	if !assert.NoError(t, err, "Error during CloneLab operation") {
+		return
+	}
+	lab, err := eveNgClient.GetLab(labPath)
+	if !assert.NoError(t, err, "Error during GetLab operation") {
+		return
+	}
+	clone, err := eveNgClient.GetLab(clonePath)
+	if assert.NoError(t, err, "Error during GetLab operation") {
+		assert.Equal(t, clonePath.Name(), clone.Name, "Cloned lab name does not match expected value")
+		assert.NotEqual(t, lab.ID, clone.ID, "Cloned lab has the same id as the original lab")
+	}
+	nodes, err := eveNgClient.GetNodes(clonePath)
+	if assert.NoError(t, err, "Error during GetNodes operation") {
+		assert.Equal(t, 1, len(nodes), "Cloned lab does not contain the node")
+	}
+}
+
+/*
+TestProfiles covers:
+	- LoadProfiles
+	- Profiles.Profile
+	- Profile.ResolveCredentials
+	- EnvCredentials
+	- FileCredentials
+	- NetrcCredentials
+	- KeyringFileCredentials
+	- CommandCredentials
+	- LegacyConfigCredentials
+*/
+func TestProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eve-ng-profiles")
+	if !assert.NoError(t, err, "Error while creating temporary directory") {
+		return
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string, mode os.FileMode) string {
+		filename := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(filename, []byte(content), mode), "Error while writing "+name)
+		return filename
+	}
+
+	profilesFile := write("profiles.yaml", `default: static
+profiles:
+  static:
+    url: https://eve-ng1.example.com
+    username: admin
+    password: eve
+  env:
+    url: https://eve-ng2.example.com
+    credentials:
+      type: env
+      prefix: EVE_NG_PROFILES_TEST
+  file:
+    url: https://eve-ng3.example.com
+    credentials:
+      type: file
+      path: `+filepath.Join(dir, "credentials.yaml")+`
+  netrc:
+    url: https://eve-ng4.example.com:8443
+    credentials:
+      type: netrc
+      path: `+filepath.Join(dir, "netrc")+`
+  keyring:
+    url: https://eve-ng5.example.com
+    username: operator
+    credentials:
+      type: keyring
+      path: `+filepath.Join(dir, "keyring.yaml")+`
+  command:
+    url: https://eve-ng6.example.com
+    credentials:
+      type: command
+      command: [echo, "secret-{profile}\nlogin: cmduser"]
+`, 0600)
+	write("credentials.yaml", "username: fileuser\npassword: filepassword\n", 0600)
+	write("netrc", "machine other.example.com login other password other\nmachine eve-ng4.example.com\n  login netrcuser\n  password netrcpassword\ndefault login anonymous password guest\n", 0600)
+	keyringFile := write("keyring.yaml", "keyring:\n  username: keyringuser\n  password: keyringpassword\n", 0644)
+	os.Setenv("EVE_NG_PROFILES_TEST_ENV_USERNAME", "envuser")
+	os.Setenv("EVE_NG_PROFILES_TEST_PASSWORD", "envpassword")
+	defer os.Unsetenv("EVE_NG_PROFILES_TEST_ENV_USERNAME")
+	defer os.Unsetenv("EVE_NG_PROFILES_TEST_PASSWORD")
+
+	profiles, err := LoadProfiles(profilesFile)
+	if !assert.NoError(t, err, "Error during LoadProfiles operation") {
+		return
+	}
+	assert.Equal(t, []string{"command", "env", "file", "keyring", "netrc", "static"}, profiles.Names(), "Profile names do not match expected value")
+
+	expected := map[string]Credentials{
+		"":        {Username: "admin", Password: "eve"},
+		"env":     {Username: "envuser", Password: "envpassword"},
+		"file":    {Username: "fileuser", Password: "filepassword"},
+		"netrc":   {Username: "netrcuser", Password: "netrcpassword"},
+		"command": {Username: "cmduser", Password: "secret-command"},
+	}
+	for name, credentials := range expected {
+		profile, err := profiles.Profile(name)
+		if !assert.NoError(t, err, "Error during Profile operation for profile '"+name+"'") {
+			continue
+		}
+		resolved, err := profile.ResolveCredentials()
+		if assert.NoError(t, err, "Error during ResolveCredentials operation for profile '"+name+"'") {
+			assert.Equal(t, credentials, resolved, "Credentials of profile '"+name+"' do not match expected value")
+		}
+	}
+
+	profile, err := profiles.Profile("keyring")
+	if assert.NoError(t, err, "Error during Profile operation") {
+		_, err = profile.ResolveCredentials()
+		assert.Error(t, err, "Keyring file accessible by other users was accepted")
+		assert.NoError(t, os.Chmod(keyringFile, 0600), "Error while changing keyring file mode")
+		resolved, err := profile.ResolveCredentials()
+		if assert.NoError(t, err, "Error during ResolveCredentials operation") {
+			assert.Equal(t, Credentials{Username: "operator", Password: "keyringpassword"}, resolved, "Keyring credentials do not match expected value")
+		}
+	}
+
+	_, err = profiles.Profile("missing")
+	_, ok := errors.Cause(err).(*ProfileNotFoundError)
+	assert.True(t, ok, "Missing profile did not return a ProfileNotFoundError")
+
+	_, err = LoadProfiles(write("invalid.yaml", "profiles:\n  invalid:\n    credentials:\n      type: vault\n", 0600))
+	assert.Error(t, err, "Unknown credential source type was accepted")
+
+	legacy := LegacyConfigCredentials{Path: write("eve-ng-api.yaml", "BaseUrl: https://eve-ng7.example.com\nUSERNAME: legacyuser\npassword: legacypassword\n", 0600)}
+	resolved, err := Profile{Name: "legacy", Provider: legacy}.ResolveCredentials()
+	if assert.NoError(t, err, "Error during ResolveCredentials operation for legacy config file") {
+		assert.Equal(t, Credentials{Username: "legacyuser", Password: "legacypassword"}, resolved, "Legacy config credentials do not match expected value")
+	}
+	resolved, err = Profile{Name: "legacy", Provider: LegacyConfigCredentials{Path: filepath.Join(dir, "missing.yaml")}}.ResolveCredentials()
+	if assert.NoError(t, err, "Missing legacy config file returned an error") {
+		assert.Equal(t, Credentials{}, resolved, "Missing legacy config file provided credentials")
+	}
+}
+
+/*
+TestTemplate_ValidateNodeSpec covers:
+	- Template.ValidateNodeSpec
+*/
+func TestTemplate_ValidateNodeSpec(t *testing.T) {
+	var template Template
+	err := json.Unmarshal([]byte(`{
+		"description": "Arista vEOS",
+		"type": "qemu",
+		"options": {
+			"name": {"name": "Name", "type": "input", "value": "vEOS"},
+			"image": {"name": "Image", "type": "list", "value": "veos-4.16.14M", "list": {"veos-4.16.14M": "veos-4.16.14M", "veos-4.20.1F": "veos-4.20.1F"}},
+			"icon": {"name": "Icon", "type": "list", "value": "AristaSW.png", "list": {"AristaSW.png": "AristaSW", "Router.png": "Router"}},
+			"console": {"name": "Console", "type": "list", "value": "telnet", "list": {"telnet": "telnet", "vnc": "vnc"}},
+			"ram": {"name": "RAM (MB)", "type": "input", "value": 2048},
+			"cpu": {"name": "CPU", "type": "input", "value": 1},
+			"ethernet": {"name": "Ethernets", "type": "input", "value": 4},
+			"delay": {"name": "Delay (s)", "type": "input", "value": 0}
+		}
+	}`), &template)
+	if !assert.NoError(t, err, "Error while unmarshalling template") {
+		return
+	}
+
+	spec, err := template.ValidateNodeSpec(NodeSpec{Template: "veos", Image: "veos-4.20.1F"})
+	if assert.NoError(t, err, "Error during ValidateNodeSpec operation") {
+		assert.Equal(t, NodeSpec{Name: "vEOS", Type: "qemu", Template: "veos", Image: "veos-4.20.1F", Icon: "AristaSW.png", Console: "telnet", RAM: 2048, CPU: 1, Ethernet: 4, Count: 1}, spec, "Node spec defaults do not match expected value")
+	}
+
+	_, err = template.ValidateNodeSpec(NodeSpec{Template: "veos", Image: "veos-4.99", Console: "rdp", CPU: 128, Delay: -1})
+	errs, ok := err.(NodeSpecErrors)
+	if assert.True(t, ok, "Invalid node spec did not return NodeSpecErrors") {
+		var fields []string
+		for _, e := range errs {
+			fields = append(fields, e.Field)
+		}
+		assert.Equal(t, []string{"image", "console", "cpu", "delay"}, fields, "Invalid fields do not match expected value")
+	}
+
+	template.Options.Image.List = ImageList{}
+	_, err = template.ValidateNodeSpec(NodeSpec{Template: "veos"})
+	if assert.Error(t, err, "Template without images was accepted") {
+		assert.Contains(t, err.Error(), "has no images installed", "Error does not mention missing images")
+	}
+}
+
+/*
+TestImageList covers:
+	- ImageList.UnmarshalJSON
+	- ImageList.Newest
+	- AvailableImages.Newest
+	- AvailableImages.WithImages
+*/
+func TestImageList(t *testing.T) {
+	var image Image
+	err := json.Unmarshal([]byte(`{"list": {"veos-4.9.3F": "veos-4.9.3F", "veos-4.20.1F": "veos-4.20.1F", "veos-4.20.1F-lab": "veos-4.20.1F-lab", "veos-4.16.14M": "veos-4.16.14M"}}`), &image)
+	if assert.NoError(t, err, "Error while unmarshalling image list object") {
+		assert.Equal(t, ImageList{"veos-4.16.14M", "veos-4.20.1F", "veos-4.20.1F-lab", "veos-4.9.3F"}, image.List, "Image list does not match expected value")
+		newest, ok := image.List.Newest()
+		assert.True(t, ok, "No newest image found")
+		assert.Equal(t, "veos-4.20.1F-lab", newest, "Newest image does not match expected value")
+		assert.True(t, image.List.Contains("veos-4.9.3F"), "Installed image not found")
+	}
+
+	err = json.Unmarshal([]byte(`{"list": []}`), &image)
+	if assert.NoError(t, err, "Error while unmarshalling empty image list") {
+		assert.Empty(t, image.List, "Empty image list is not empty")
+	}
+
+	images := AvailableImages{"veos": {"veos-4.16.14M", "veos-4.20.1F"}, "vios": {}}
+	newest, err := images.Newest("veos")
+	if assert.NoError(t, err, "Error during Newest operation") {
+		assert.Equal(t, "veos-4.20.1F", newest, "Newest image does not match expected value")
+	}
+	_, err = images.Newest("vios")
+	assert.IsType(t, &NoImagesInstalledError{}, err, "Template without images did not return a NoImagesInstalledError")
+	assert.Equal(t, []string{"veos"}, images.WithImages(), "Templates with images do not match expected value")
+}
+
+/*
+TestEveNgClient_GetAvailableImages covers:
+	- GetAvailableImages
+*/
+func TestEveNgClient_GetAvailableImages(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	templates, err := eveNgClient.GetNodeTemplates()
+	if !assert.NoError(t, err, "Error during GetNodeTemplates operation") {
+		return
+	}
+	images, err := eveNgClient.GetAvailableImages()
+	if assert.NoError(t, err, "Error during GetAvailableImages operation") {
+		assert.Equal(t, len(templates), len(images), "Not all templates are contained in the available images")
+		assert.True(t, images["asav"].Contains("asav-952-204"), "Image used by the node tests is not installed")
+	}
+}
+
+/*
+TestTemplateCatalog covers:
+	- ReadTemplateCatalog
+	- TemplateCatalog.Get
+	- TemplateCatalog.Search
+	- TemplateCatalog.WithImages
+	- TemplateCatalog.ValidateNodeSpec
+	- TemplateCatalog.WriteJSON
+*/
+func TestTemplateCatalog(t *testing.T) {
+	catalog, err := ReadTemplateCatalog(bytes.NewReader([]byte(`{
+		"loaded": "2020-02-20T10:00:00Z",
+		"templates": [
+			{"name": "asav", "description": "Cisco ASAv", "vendor": "Cisco", "type": "qemu", "images": ["asav-952-204"],
+				"template": {"type": "qemu", "options": {"image": {"name": "Image", "value": "asav-952-204", "list": {"asav-952-204": ""}}}}},
+			{"name": "veos", "description": "Arista vEOS", "vendor": "Arista", "type": "qemu", "images": []},
+			{"name": "vios", "description": "Cisco vIOS Router", "vendor": "Cisco", "type": "qemu", "images": ["vios-adventerprisek9-m-15.6"]}
+		]
+	}`)))
+	if !assert.NoError(t, err, "Error during ReadTemplateCatalog operation") {
+		return
+	}
+
+	template, err := catalog.Get("veos")
+	if assert.NoError(t, err, "Error during Get operation") {
+		assert.Equal(t, "Arista vEOS", template.Description, "Template description does not match expected value")
+		assert.False(t, template.HasImages(), "Template without images has images")
+	}
+	_, err = catalog.Get("missing")
+	assert.IsType(t, &TemplateNotFoundError{}, err, "Missing template did not return a TemplateNotFoundError")
+
+	names := func(templates []CatalogTemplate) []string {
+		var names []string
+		for _, template := range templates {
+			names = append(names, template.Name)
+		}
+		return names
+	}
+	templates, err := catalog.Search(TemplateQuery{Vendor: "cisco"})
+	if assert.NoError(t, err, "Error during Search operation") {
+		assert.Equal(t, []string{"asav", "vios"}, names(templates), "Templates of vendor do not match expected value")
+	}
+	templates, err = catalog.Search(TemplateQuery{Description: "router", Type: "qemu"})
+	if assert.NoError(t, err, "Error during Search operation") {
+		assert.Equal(t, []string{"vios"}, names(templates), "Templates matching description do not match expected value")
+	}
+	templates, err = catalog.WithImages()
+	if assert.NoError(t, err, "Error during WithImages operation") {
+		assert.Equal(t, []string{"asav", "vios"}, names(templates), "Templates with images do not match expected value")
+	}
+
+	spec, err := catalog.ValidateNodeSpec(NodeSpec{Template: "asav", Name: "FW"})
+	if assert.NoError(t, err, "Error during ValidateNodeSpec operation") {
+		assert.Equal(t, "asav-952-204", spec.Image, "Default image does not match expected value")
+	}
+	_, err = catalog.ValidateNodeSpec(NodeSpec{Template: "missing"})
+	assert.IsType(t, NodeSpecErrors{}, err, "Missing template did not return NodeSpecErrors")
+
+	var buffer bytes.Buffer
+	if assert.NoError(t, catalog.WriteJSON(&buffer), "Error during WriteJSON operation") {
+		reread, err := ReadTemplateCatalog(&buffer)
+		if assert.NoError(t, err, "Error while reading written catalog") {
+			assert.Equal(t, catalog.Loaded(), reread.Loaded(), "Load time of written catalog does not match")
+			rereadTemplates, _ := reread.Templates()
+			templates, _ = catalog.Templates()
+			assert.Equal(t, templates, rereadTemplates, "Templates of written catalog do not match")
+		}
+	}
+}
+
+/*
+TestTemplateCatalogLoad covers:
+	- EveNgClient.NewTemplateCatalog
+	- TemplateCatalog.Templates
+*/
+func TestTemplateCatalogLoad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/list/templates/":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"vios":"Cisco vIOS Router","veos":"Arista vEOS.missing"}}`))
+		case "/api/list/templates/vios":
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"","data":{"type":"qemu","options":{"image":{"name":"Image","value":"vios-15.6","list":{"vios-15.6":""}}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"status":"fail","message":"Template not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	eveNgClient, err := NewEveNgClient(server.URL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	templates, err := eveNgClient.NewTemplateCatalog(time.Minute).Templates()
+	if assert.NoError(t, err, "Error during Templates operation") && assert.Equal(t, 2, len(templates), "Number of templates does not match expected value") {
+		assert.Equal(t, "veos", templates[0].Name, "Template name does not match expected value")
+		assert.Equal(t, "Arista vEOS", templates[0].Description, "Description of missing template does not match expected value")
+		assert.Equal(t, ImageList{}, templates[0].Images, "Missing template has images")
+		assert.Equal(t, ImageList{"vios-15.6"}, templates[1].Images, "Template images do not match expected value")
+		assert.Equal(t, "qemu", templates[1].Type, "Template type does not match expected value")
+	}
+}
+
+/*
+TestEveNgClient_TemplateCatalog covers:
+	- NewTemplateCatalog
+	- TemplateCatalog.Refresh
+*/
+func TestEveNgClient_TemplateCatalog(t *testing.T) {
+	eveNgClient, err := NewEveNgClient(testProfile().BaseURL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+
+	err = eveNgClient.SetUsernameAndPassword(testCredentials().Username, testCredentials().Password)
+	if !assert.NoError(t, err, "Error while setting username and password") {
+		return
+	}
+
+	err = eveNgClient.Login()
+	if !assert.NoError(t, err, "Error during login") {
+		return
+	}
+	defer func() {
+		err = eveNgClient.Logout()
+		if !assert.NoError(t, err, "Error during logout") {
+			return
+		}
+	}()
+
+	catalog := eveNgClient.NewTemplateCatalog(time.Minute)
+	templates, err := catalog.Templates()
+	if !assert.NoError(t, err, "Error during Templates operation") {
+		return
+	}
+	assert.NotEmpty(t, templates, "Template catalog is empty")
+	loaded := catalog.Loaded()
+	_, err = catalog.Templates()
+	if assert.NoError(t, err, "Error during cached Templates operation") {
+		assert.Equal(t, loaded, catalog.Loaded(), "Cached catalog was reloaded")
+	}
+	if assert.NoError(t, catalog.Refresh(), "Error during Refresh operation") {
+		assert.True(t, catalog.Loaded().After(loaded), "Refreshed catalog was not reloaded")
+	}
+	template, err := catalog.Get("asav")
+	if assert.NoError(t, err, "Error during Get operation") {
+		assert.Equal(t, "Cisco", template.Vendor, "Template vendor does not match expected value")
+	}
+}
+
+/*
+TestResponseCache covers:
+	- WithCache
+	- InvalidateCache
+	- ClearCache
+*/
+func TestResponseCache(t *testing.T) {
+	assert.Equal(t, cachePath{endpoint: CacheEndpointNodes, lab: "Folder/Lab.unl", resource: "nodes/1/interfaces"}, parseCachePath("api/labs/Folder/Lab.unl/nodes/1/interfaces"), "Parsed node interfaces path does not match expected value")
+	assert.Equal(t, cachePath{endpoint: CacheEndpointLab, lab: "Lab.unl"}, parseCachePath("api/labs/Lab.unl"), "Parsed lab path does not match expected value")
+	assert.Equal(t, cachePath{endpoint: CacheEndpointFolders}, parseCachePath("api/folders/Folder"), "Parsed folder path does not match expected value")
+	assert.True(t, parseCachePath("api/labs/Lab.unl/nodes/1/stop/stopmode=3").hasSideEffects(), "Stopping a node is not recognized as write")
+
+	eveNgClient, err := NewEveNgClient("http://localhost")
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	cachedClient := eveNgClient.WithCache(CacheOptions{TTLs: map[CacheEndpoint]time.Duration{
+		CacheEndpointNodes:   time.Minute,
+		CacheEndpointStatus:  time.Minute,
+		CacheEndpointFolders: time.Minute,
+		CacheEndpointLab:     time.Millisecond,
+	}})
+	assert.Nil(t, eveNgClient.cache, "WithCache changed the original client")
+	cache := cachedClient.cache
+
+	var mutex sync.Mutex
+	fetches := make(map[string]int)
+	get := func(method, path string) {
+		_, err := cache.do(method, path, func() (*resty.Response, error) {
+			mutex.Lock()
+			fetches[method+" "+path]++
+			mutex.Unlock()
+			return &resty.Response{}, nil
+		})
+		assert.NoError(t, err, "Error during cached request")
+	}
+	count := func(method, path string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return fetches[method+" "+path]
+	}
+
+	nodesA, nodesB := "api/labs/A.unl/nodes", "api/labs/B.unl/nodes"
+	for i := 0; i < 3; i++ {
+		get("GET", nodesA)
+		get("GET", nodesB)
+		get("GET", "api/status")
+		get("GET", "api/labs/A.unl")
+		time.Sleep(2 * time.Millisecond)
+	}
+	assert.Equal(t, 1, count("GET", nodesA), "Cached response was fetched again")
+	assert.True(t, count("GET", "api/labs/A.unl") > 1, "Expired response was not fetched again")
+
+	get("GET", "api/labs/A.unl/nodes/1/start")
+	get("GET", "api/labs/A.unl/nodes/1/start")
+	assert.Equal(t, 2, count("GET", "api/labs/A.unl/nodes/1/start"), "Node start was cached")
+	get("GET", nodesA)
+	get("GET", nodesB)
+	get("GET", "api/status")
+	assert.Equal(t, 2, count("GET", nodesA), "Nodes of started lab were not invalidated")
+	assert.Equal(t, 1, count("GET", nodesB), "Nodes of other lab were invalidated")
+	assert.Equal(t, 2, count("GET", "api/status"), "Status was not invalidated after starting a node")
+
+	get("PUT", "api/labs/B.unl/networks/1")
+	get("GET", nodesB)
+	assert.Equal(t, 2, count("GET", nodesB), "Entries of changed lab were not invalidated")
+	cachedClient.InvalidateCache("/B.unl")
+	get("GET", nodesB)
+	assert.Equal(t, 3, count("GET", nodesB), "InvalidateCache did not invalidate the lab")
+	cachedClient.ClearCache()
+	get("GET", nodesA)
+	assert.Equal(t, 3, count("GET", nodesA), "ClearCache did not invalidate all entries")
+
+	// concurrent reads are coalesced, a read in flight during a write is not cached
+	release := make(chan struct{})
+	var wait sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			_, _ = cache.do("GET", "api/folders/", func() (*resty.Response, error) {
+				<-release
+				mutex.Lock()
+				fetches["GET api/folders/"]++
+				mutex.Unlock()
+				return &resty.Response{}, nil
+			})
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	get("POST", "api/folders")
+	close(release)
+	wait.Wait()
+	assert.Equal(t, 1, count("GET", "api/folders/"), "Concurrent reads were not coalesced")
+	get("GET", "api/folders/")
+	assert.Equal(t, 2, count("GET", "api/folders/"), "Read in flight during a write was cached")
+}
+
+/*
+TestRequestLimits covers:
+	- SetRateLimit
+	- SetMaxConcurrentRequests
+	- WithContext
+*/
+func TestRequestLimits(t *testing.T) {
+	eveNgClient, err := NewEveNgClient("http://localhost")
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	assert.Error(t, eveNgClient.SetRateLimit(-1, 1), "Negative rate limit was accepted")
+	assert.Error(t, eveNgClient.SetMaxConcurrentRequests(-1), "Negative maximum of concurrent requests was accepted")
+
+	err = eveNgClient.SetRateLimit(20, 2)
+	if !assert.NoError(t, err, "Error during SetRateLimit operation") {
+		return
+	}
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := eveNgClient.limits.acquire(context.Background())
+		if assert.NoError(t, err, "Error while waiting for the rate limit") {
+			release()
+		}
+	}
+	elapsed := time.Since(start)
+	assert.True(t, elapsed >= 90*time.Millisecond, "Requests exceeding the burst did not wait for the rate limit")
+	assert.True(t, elapsed < time.Second, "Requests waited too long for the rate limit")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = eveNgClient.limits.acquire(ctx)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err), "Waiting for the rate limit was not canceled")
+	assert.NoError(t, eveNgClient.SetRateLimit(0, 0), "Error while removing the rate limit")
+
+	err = eveNgClient.SetMaxConcurrentRequests(2)
+	if !assert.NoError(t, err, "Error during SetMaxConcurrentRequests operation") {
+		return
+	}
+	first, err := eveNgClient.limits.acquire(context.Background())
+	assert.NoError(t, err, "Error while acquiring a request slot")
+	second, err := eveNgClient.limits.acquire(context.Background())
+	assert.NoError(t, err, "Error while acquiring a request slot")
+
+	acquired := make(chan struct{})
+	go func() {
+		third, err := eveNgClient.limits.acquire(context.Background())
+		if assert.NoError(t, err, "Error while acquiring a released request slot") {
+			third()
+		}
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Error("More requests than allowed were in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	first()
+	<-acquired
+	second()
+
+	// a request of a canceled client gives up waiting for a request slot
+	assert.NoError(t, eveNgClient.SetMaxConcurrentRequests(1), "Error during SetMaxConcurrentRequests operation")
+	slot, _ := eveNgClient.limits.acquire(context.Background())
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = eveNgClient.WithContext(canceled).GetSystemStatus()
+	assert.Equal(t, context.Canceled, errors.Cause(err), "Request of canceled client did not stop waiting")
+	slot()
+}
+
+/*
+testLogger records the messages of a Logger by level
+*/
+type testLogger struct {
+	mutex    sync.Mutex
+	messages map[string][]string
+}
+
+func (l *testLogger) log(level, msg string, args ...interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.messages == nil {
+		l.messages = make(map[string][]string)
+	}
+	l.messages[level] = append(l.messages[level], fmt.Sprint(append([]interface{}{msg}, args...)...))
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("debug", msg, args...) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("info", msg, args...) }
+func (l *testLogger) Warn(msg string, args ...interface{})  { l.log("warn", msg, args...) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("error", msg, args...) }
+
+/*
+TestLogging covers:
+	- SetLogger
+	- SetHooks
+	- redactBody
+*/
+func TestLogging(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/users/flaky" && atomic.AddInt32(&attempts, 1) == 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.HasPrefix(r.URL.Path, "/api/users/"):
+			_, _ = w.Write([]byte(`{"code":200,"status":"success","message":"ok","data":{"username":"admin","password":"secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"status":"fail","message":"Lab does not exist"}`))
+		}
+	}))
+	defer server.Close()
+
+	eveNgClient, err := NewEveNgClient(server.URL)
+	if !assert.NoError(t, err, "Error while creating API client") {
+		return
+	}
+	logger := &testLogger{}
+	if !assert.NoError(t, eveNgClient.SetLogger(logger), "Error during SetLogger operation") {
+		return
+	}
+	var (
+		mutex     sync.Mutex
+		requests  []RequestInfo
+		responses []ResponseInfo
+	)
+	err = eveNgClient.SetHooks(Hooks{
+		OnRequest: func(info RequestInfo) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			requests = append(requests, info)
+		},
+		OnResponse: func(info ResponseInfo) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			responses = append(responses, info)
+		},
+	})
+	if !assert.NoError(t, err, "Error during SetHooks operation") {
+		return
+	}
+
+	err = eveNgClient.EditUser("admin", "Admin", "admin@example.com", "hunter2", "admin", "-1", 0, "-1")
+	if assert.NoError(t, err, "Error during EditUser operation") && assert.Len(t, responses, 1, "Number of reported responses does not match expected value") {
+		assert.Equal(t, "PUT", requests[0].Method, "Reported request method does not match expected value")
+		assert.Equal(t, "api/users/admin", requests[0].Path, "Reported request path does not match expected value")
+		assert.NotContains(t, requests[0].Body, "hunter2", "Password in request body was not redacted")
+		assert.Contains(t, requests[0].Body, `"name":"Admin"`, "Request body is missing")
+		assert.Equal(t, 200, responses[0].StatusCode, "Reported status code does not match expected value")
+		assert.NotContains(t, responses[0].ResponseBody, "secret", "Password in response body was not redacted")
+		assert.Len(t, logger.messages["debug"], 1, "Successful request was not logged")
+	}
+
+	err = eveNgClient.RemoveLab(LabPath("/Missing.unl"))
+	if assert.Error(t, err, "Removing a missing lab did not fail") {
+		assert.Contains(t, err.Error(), "error while removing lab /Missing.unl", "Error does not name the operation")
+		assert.Contains(t, err.Error(), "DELETE", "Error does not name the http method")
+		assert.Len(t, logger.messages["warn"], 1, "Failed request was not logged")
+		if assert.Len(t, responses, 2, "Number of reported responses does not match expected value") {
+			assert.Equal(t, 404, responses[1].StatusCode, "Reported status code does not match expected value")
+			assert.Error(t, responses[1].Err, "Reported error is missing")
+		}
+	}
+
+	eveNgClient.resty.SetRetryCount(2).AddRetryCondition(func(response *resty.Response, err error) bool {
+		return response != nil && response.StatusCode() == http.StatusInternalServerError
+	})
+	_, err = eveNgClient.GetUser("flaky")
+	if assert.NoError(t, err, "Error during retried GetUser operation") && assert.Len(t, responses, 3, "Number of reported responses does not match expected value") {
+		assert.Equal(t, 1, responses[2].Retries, "Reported retry count does not match expected value")
+	}
+
+	assert.Equal(t, `{"name":"lab","nodes":[{"rdp_password":"[REDACTED]"}]}`, redactBody([]byte(`{"name":"lab","nodes":[{"rdp_password":"x"}]}`)), "Nested password was not redacted")
+	assert.Equal(t, "[3 bytes]", redactBody([]byte{0xff, 0xfe, 0xfd}), "Binary body was not replaced by its length")
+	assert.Len(t, redactBody(bytes.Repeat([]byte("a"), 2*maxLoggedBodyLength)), maxLoggedBodyLength+len("...[truncated]"), "Long body was not truncated")
+}
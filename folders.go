@@ -0,0 +1,409 @@
+package evengclient
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*
+SkipFolder can be returned by a WalkFunc for a folder to skip the contents of that folder
+*/
+var SkipFolder = errors.New("skip this folder")
+
+/*
+WalkEntry is a folder or lab visited by WalkFolders. Path is the absolute path of the entry, Depth is 0 for the root
+folder and increases by one per folder level.
+*/
+type WalkEntry struct {
+	Name  string
+	Path  string
+	Depth int
+	IsLab bool
+}
+
+/*
+WalkFunc is called by WalkFolders for every visited folder and lab
+*/
+type WalkFunc func(entry WalkEntry) error
+
+/*
+WalkOptions limits a folder walk. MaxDepth is the deepest folder level whose contents are listed, 0 means unlimited.
+Concurrency is the number of folders listed in parallel and defaults to 4.
+*/
+type WalkOptions struct {
+	MaxDepth    int
+	Concurrency int
+}
+
+/*
+FolderTree is a folder with all its labs and subfolders
+*/
+type FolderTree struct {
+	Name    string
+	Path    FolderPath
+	Labs    LabFiles
+	Folders []*FolderTree
+}
+
+/*
+RemoveFolderOptions controls RemoveFolderRecursive. With DryRun set nothing is stopped or removed, the returned
+report lists what would be.
+*/
+type RemoveFolderOptions struct {
+	DryRun bool
+}
+
+/*
+FolderRemovalReport lists what RemoveFolderRecursive stopped and removed, in the order it happened
+*/
+type FolderRemovalReport struct {
+	DryRun      bool
+	StoppedLabs []LabPath
+	Labs        []LabPath
+	Folders     []FolderPath
+}
+
+/*
+WalkFolders recursively visits all folders and labs below root with the default options. See WalkFoldersWithOptions.
+*/
+func (c *EveNgClient) WalkFolders(ctx context.Context, root FolderPath, fn WalkFunc) error {
+	return c.WalkFoldersWithOptions(ctx, root, WalkOptions{}, fn)
+}
+
+/*
+WalkFoldersWithOptions recursively visits all folders and labs below root, starting with root itself. A folder is
+visited before its contents. Folders are listed concurrently, but fn is never called concurrently, so it needs no
+synchronization; the order of entries from different folders is not deterministic. If fn returns SkipFolder for a
+folder, its contents are skipped. Any other error, a failed folder listing or the cancellation of ctx stop the walk
+and the first error is returned.
+*/
+func (c *EveNgClient) WalkFoldersWithOptions(ctx context.Context, root FolderPath, options WalkOptions, fn WalkFunc) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 4
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	walk := &folderWalk{
+		client:    c,
+		ctx:       ctx,
+		cancel:    cancel,
+		options:   options,
+		fn:        fn,
+		semaphore: make(chan struct{}, options.Concurrency),
+	}
+	walk.visitFolder(WalkEntry{Name: root.Base(), Path: root.String()})
+	walk.wait.Wait()
+
+	if walk.err != nil {
+		return walk.err
+	}
+	return errors.Wrap(ctx.Err(), "folder walk canceled")
+}
+
+/*
+GetFolderTree returns the folder tree below root. maxDepth limits the folder levels whose contents are listed, 0
+means unlimited.
+*/
+func (c *EveNgClient) GetFolderTree(ctx context.Context, root FolderPath, maxDepth int) (*FolderTree, error) {
+	var tree *FolderTree
+	folders := make(map[string]*FolderTree)
+	err := c.WalkFoldersWithOptions(ctx, root, WalkOptions{MaxDepth: maxDepth}, func(entry WalkEntry) error {
+		if entry.IsLab {
+			folder := folders[path.Dir(entry.Path)]
+			folder.Labs = append(folder.Labs, LabFile{File: entry.Name, Path: entry.Path})
+			return nil
+		}
+		folder := &FolderTree{Name: entry.Name, Path: FolderPath(entry.Path)}
+		folders[entry.Path] = folder
+		if entry.Depth == 0 {
+			tree = folder
+			return nil
+		}
+		parent := folders[path.Dir(entry.Path)]
+		parent.Folders = append(parent.Folders, folder)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while walking folders")
+	}
+	tree.sort()
+	return tree, nil
+}
+
+/*
+FindLabs returns all labs below root which match the given shell pattern (see path.Match). A pattern containing a
+slash is matched against the absolute lab path, any other pattern against the lab file name.
+*/
+func (c *EveNgClient) FindLabs(root FolderPath, pattern string) (LabFiles, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Wrap(err, "invalid pattern")
+	}
+	matchPath := strings.Contains(pattern, "/")
+	labs := LabFiles{}
+	err := c.WalkFolders(context.Background(), root, func(entry WalkEntry) error {
+		if !entry.IsLab {
+			return nil
+		}
+		name := entry.Name
+		if matchPath {
+			name = entry.Path
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			labs = append(labs, LabFile{File: entry.Name, Path: entry.Path})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while walking folders")
+	}
+	sort.Slice(labs, func(a, b int) bool {
+		return labs[a].Path < labs[b].Path
+	})
+	return labs, nil
+}
+
+/*
+EnsureFolder creates the given folder and every missing parent folder. Folders which already exist are left as they
+are, so calling it repeatedly is safe.
+*/
+func (c *EveNgClient) EnsureFolder(folderPath FolderPath) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	parent := RootFolder
+	for _, name := range folderPath.Segments() {
+		exists, err := c.folderExists(parent, name)
+		if err != nil {
+			return errors.Wrap(err, "error while checking folder "+parent.Join(name).String())
+		}
+		if !exists {
+			err = c.AddFolder(parent, name)
+			if err != nil {
+				// the folder may have been created concurrently
+				if exists, _ := c.folderExists(parent, name); !exists {
+					return errors.Wrap(err, "error while creating folder "+parent.Join(name).String())
+				}
+			}
+		}
+		parent = parent.Join(name)
+	}
+	return nil
+}
+
+/*
+RemoveFolderRecursive removes a folder with all its contents. Running nodes are stopped before their lab is removed,
+labs and subfolders are removed bottom-up. The root folder can not be removed.
+*/
+func (c *EveNgClient) RemoveFolderRecursive(folderPath FolderPath, options RemoveFolderOptions) (FolderRemovalReport, error) {
+	report := FolderRemovalReport{DryRun: options.DryRun}
+	if !c.isValid() {
+		return report, &NotValidError{}
+	}
+	if folderPath.IsRoot() {
+		return report, errors.New("the root folder can not be removed")
+	}
+	tree, err := c.GetFolderTree(context.Background(), folderPath, 0)
+	if err != nil {
+		return report, errors.Wrap(err, "error while retrieving folder tree")
+	}
+	err = c.removeFolderTree(tree, options, &report)
+	return report, err
+}
+
+/*
+LabPaths returns the paths of all labs in the tree
+*/
+func (t *FolderTree) LabPaths() []LabPath {
+	var paths []LabPath
+	for _, lab := range t.Labs {
+		paths = append(paths, lab.LabPath())
+	}
+	for _, folder := range t.Folders {
+		paths = append(paths, folder.LabPaths()...)
+	}
+	return paths
+}
+
+//---------- helper functions ----------//
+
+/*
+folderWalk - State of a running folder walk
+*/
+type folderWalk struct {
+	client    *EveNgClient
+	ctx       context.Context
+	cancel    context.CancelFunc
+	options   WalkOptions
+	fn        WalkFunc
+	semaphore chan struct{}
+	wait      sync.WaitGroup
+
+	mutex sync.Mutex
+	err   error
+}
+
+/*
+visitFolder - Visits a folder and lists its contents unless the walk was stopped
+*/
+func (w *folderWalk) visitFolder(folder WalkEntry) {
+	err := w.call(folder)
+	if err == SkipFolder {
+		return
+	}
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if w.options.MaxDepth > 0 && folder.Depth >= w.options.MaxDepth {
+		return
+	}
+	w.wait.Add(1)
+	go func() {
+		defer w.wait.Done()
+		w.listFolder(folder)
+	}()
+}
+
+/*
+listFolder - Lists the contents of a folder, visits its labs and descends into its subfolders
+*/
+func (w *folderWalk) listFolder(folder WalkEntry) {
+	select {
+	case w.semaphore <- struct{}{}:
+	case <-w.ctx.Done():
+		return
+	}
+	contents, err := w.client.getFolderContents(FolderPath(folder.Path))
+	<-w.semaphore
+	if err != nil {
+		w.fail(errors.Wrap(err, "error while listing folder "+folder.Path))
+		return
+	}
+
+	for _, lab := range contents.LabFiles {
+		err = w.call(WalkEntry{Name: lab.File, Path: path.Join(folder.Path, lab.File), Depth: folder.Depth + 1, IsLab: true})
+		if err == SkipFolder {
+			return
+		}
+		if err != nil {
+			w.fail(err)
+			return
+		}
+	}
+	for _, subfolder := range contents.Folders {
+		if subfolder.Name == ".." || subfolder.Name == "." {
+			continue
+		}
+		w.visitFolder(WalkEntry{Name: subfolder.Name, Path: path.Join(folder.Path, subfolder.Name), Depth: folder.Depth + 1})
+	}
+}
+
+/*
+call - Calls the walk function unless the walk was stopped
+*/
+func (w *folderWalk) call(entry WalkEntry) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.err != nil || w.ctx.Err() != nil {
+		return SkipFolder
+	}
+	return w.fn(entry)
+}
+
+/*
+fail - Stops the walk and records the first error
+*/
+func (w *folderWalk) fail(err error) {
+	w.mutex.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.mutex.Unlock()
+	w.cancel()
+}
+
+/*
+sort - Sorts labs and subfolders of the tree by name
+*/
+func (t *FolderTree) sort() {
+	sort.Slice(t.Labs, func(a, b int) bool {
+		return t.Labs[a].File < t.Labs[b].File
+	})
+	sort.Slice(t.Folders, func(a, b int) bool {
+		return t.Folders[a].Name < t.Folders[b].Name
+	})
+	for _, folder := range t.Folders {
+		folder.sort()
+	}
+}
+
+/*
+folderExists - Checks whether a parent folder contains a folder with the given name
+*/
+func (c *EveNgClient) folderExists(parent FolderPath, name string) (bool, error) {
+	folders, err := c.GetFolders(parent)
+	if err != nil {
+		return false, err
+	}
+	for _, folder := range folders {
+		if folder.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+/*
+removeFolderTree - Removes the subfolders and labs of a folder tree and the folder itself
+*/
+func (c *EveNgClient) removeFolderTree(tree *FolderTree, options RemoveFolderOptions, report *FolderRemovalReport) error {
+	for _, folder := range tree.Folders {
+		err := c.removeFolderTree(folder, options, report)
+		if err != nil {
+			return err
+		}
+	}
+	for _, lab := range tree.Labs {
+		labPath := lab.LabPath()
+		nodes, err := c.GetNodes(labPath)
+		if err != nil {
+			return errors.Wrap(err, "error while retrieving nodes of lab "+labPath.String())
+		}
+		for _, node := range nodes {
+			if node.Status != NodeStatusStopped {
+				if !options.DryRun {
+					err = c.StopNodes(labPath)
+					if err != nil {
+						return errors.Wrap(err, "error while stopping nodes of lab "+labPath.String())
+					}
+				}
+				report.StoppedLabs = append(report.StoppedLabs, labPath)
+				break
+			}
+		}
+		if !options.DryRun {
+			err = c.RemoveLab(labPath)
+			if err != nil {
+				return errors.Wrap(err, "error while removing lab "+labPath.String())
+			}
+		}
+		report.Labs = append(report.Labs, labPath)
+	}
+	if !options.DryRun {
+		err := c.RemoveFolder(tree.Path)
+		if err != nil {
+			return errors.Wrap(err, "error while removing folder "+tree.Path.String())
+		}
+	}
+	report.Folders = append(report.Folders, tree.Path)
+	return nil
+}
@@ -1,6 +1,7 @@
 package evengclient
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"strconv"
@@ -31,11 +32,24 @@ func NewEveNgClient(baseURL string) (*EveNgClient, error) {
 	if lastChar := baseURL[len(baseURL)-1:]; lastChar != "/" {
 		baseURL += "/"
 	}
-	clientData := clientData{baseURL: baseURL, resty: resty.New(), useAuth: false}
+	clientData := clientData{baseURL: baseURL, resty: resty.New().OnBeforeRequest(countAttempt), useAuth: false, limits: &requestLimits{}, observer: &requestObserver{}}
 	newClient := client{&clientData}
 	return &EveNgClient{newClient}, nil
 }
 
+/*
+WithContext returns a client which shares the connection, session, cache and limits of c, but sends its requests with
+the given context. Waiting for the rate limit or a free request slot is aborted when the context is canceled.
+*/
+func (c *EveNgClient) WithContext(ctx context.Context) *EveNgClient {
+	if !c.isValid() {
+		return c
+	}
+	data := *c.clientData
+	data.ctx = ctx
+	return &EveNgClient{client{&data}}
+}
+
 /*
 Login performs a login via an eve-ng api-client
 */
@@ -54,7 +68,7 @@ func (c *EveNgClient) Login() error {
 	}
 	_, err = c.request("POST", endpointPath+"auth/login", `{"username":"`+escapedUsername+`","password":"`+escapedPassword+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http login request")
+		return errors.Wrap(err, "error while logging in as "+c.username)
 	}
 	return nil
 }
@@ -68,7 +82,7 @@ func (c *EveNgClient) Logout() error {
 	}
 	_, err := c.request("GET", endpointPath+"auth/logout", "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http loqout request")
+		return errors.Wrap(err, "error while logging out")
 	}
 	return nil
 }
@@ -84,7 +98,7 @@ func (c *EveNgClient) GetSystemStatus() (SystemStatus, error) {
 	}
 	response, err := c.request("GET", endpointPath+"status", "", nil, nil)
 	if err != nil {
-		return SystemStatus{}, errors.Wrap(err, "error during http get system status request")
+		return SystemStatus{}, errors.Wrap(err, "error while retrieving system status")
 	}
 	var systemStatusResponse SystemStatus
 	err = c.unmarshalDataIntoStruct(response.Body(), &systemStatusResponse)
@@ -99,13 +113,13 @@ func (c *EveNgClient) GetSystemStatus() (SystemStatus, error) {
 /*
 AddLab adds a lab to
 */
-func (c *EveNgClient) AddLab(path string, name string, version string, author string, description string, body string) error {
+func (c *EveNgClient) AddLab(path FolderPath, name string, version string, author string, description string, body string) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("POST", endpointPath+"labs", `{"path":"`+path+`","name":"`+name+`","version":"`+version+`","author":"`+author+`","description":"`+description+`","body":"`+body+`"}`, nil, nil)
+	_, err := c.request("POST", endpointPath+"labs", `{"path":"`+path.String()+`","name":"`+name+`","version":"`+version+`","author":"`+author+`","description":"`+description+`","body":"`+body+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while adding lab "+path.Lab(name).String())
 	}
 
 	return nil
@@ -114,13 +128,13 @@ func (c *EveNgClient) AddLab(path string, name string, version string, author st
 /*
 RemoveLab remove an existing lab
 */
-func (c *EveNgClient) RemoveLab(labPath string) error {
+func (c *EveNgClient) RemoveLab(labPath LabPath) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("DELETE", endpointPath+"labs/"+labPath, "", nil, nil)
+	_, err := c.request("DELETE", endpointPath+"labs/"+labPath.api(), "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while removing lab "+labPath.String())
 	}
 
 	return nil
@@ -129,13 +143,13 @@ func (c *EveNgClient) RemoveLab(labPath string) error {
 /*
 MoveLab moves a lab to an existing folder
 */
-func (c *EveNgClient) MoveLab(labPath string, newPath string) error {
+func (c *EveNgClient) MoveLab(labPath LabPath, newPath FolderPath) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	response, err := c.request("PUT", endpointPath+"labs/"+labPath+"/move", `{"path":"`+newPath+`"}`, nil, nil)
+	response, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"/move", `{"path":"`+newPath.String()+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while moving lab "+labPath.String()+" to folder "+newPath.String())
 	}
 	var lab Lab
 	err = c.unmarshalDataIntoStruct(response.Body(), &lab)
@@ -148,13 +162,13 @@ func (c *EveNgClient) MoveLab(labPath string, newPath string) error {
 /*
 EditLab edit an existing lab
 */
-func (c *EveNgClient) EditLab(labPath string, name string, version string, author string, description string) error {
+func (c *EveNgClient) EditLab(labPath LabPath, name string, version string, author string, description string) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	response, err := c.request("PUT", endpointPath+"labs/"+labPath+"", `{"name":"`+name+`","version":"`+version+`","author":"`+author+`","description":"`+description+`"}`, nil, nil)
+	response, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"", `{"name":"`+name+`","version":"`+version+`","author":"`+author+`","description":"`+description+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while editing lab "+labPath.String())
 	}
 	var lab Lab
 	err = c.unmarshalDataIntoStruct(response.Body(), &lab)
@@ -167,13 +181,13 @@ func (c *EveNgClient) EditLab(labPath string, name string, version string, autho
 /*
 GetLab retrieves data for the given lab
 */
-func (c *EveNgClient) GetLab(labPath string) (Lab, error) {
+func (c *EveNgClient) GetLab(labPath LabPath) (Lab, error) {
 	if !c.isValid() {
 		return Lab{}, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"", "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"", "", nil, nil)
 	if err != nil {
-		return Lab{}, errors.Wrap(err, "error during http get request")
+		return Lab{}, errors.Wrap(err, "error while retrieving lab "+labPath.String())
 	}
 	var lab Lab
 	err = c.unmarshalDataIntoStruct(response.Body(), &lab)
@@ -186,13 +200,13 @@ func (c *EveNgClient) GetLab(labPath string) (Lab, error) {
 /*
 GetTopology retrieves topology for given lab
 */
-func (c *EveNgClient) GetTopology(labPath string) (TopologyPoints, error) {
+func (c *EveNgClient) GetTopology(labPath LabPath) (TopologyPoints, error) {
 	if !c.isValid() {
 		return nil, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/topology", "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/topology", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving topology of lab "+labPath.String())
 	}
 	var topologyPoints TopologyPoints
 	err = c.unmarshalDataIntoStruct(response.Body(), &topologyPoints)
@@ -207,13 +221,13 @@ func (c *EveNgClient) GetTopology(labPath string) (TopologyPoints, error) {
 /*
 AddNode add a new node to a lab
 */
-func (c *EveNgClient) AddNode(labPath string, nodeType string, template string, config string, delay int, icon string, image string, name string, left int, top int, ram int, console string, cpu int, cpuLimit string, ethernet int, firstMac string, rdpUser string, rdpPassword string, uuid string, count int) (int, error) {
+func (c *EveNgClient) AddNode(labPath LabPath, nodeType string, template string, config string, delay int, icon string, image string, name string, left int, top int, ram int, console string, cpu int, cpuLimit string, ethernet int, firstMac string, rdpUser string, rdpPassword string, uuid string, count int) (int, error) {
 	if !c.isValid() {
 		return 0, &NotValidError{}
 	}
-	response, err := c.request("POST", endpointPath+"labs/"+labPath+"/nodes", `{"path":"`+labPath+`","type":"`+nodeType+`","template":"`+template+`","config":"`+config+`","delay":"`+strconv.Itoa(delay)+`","icon":"`+icon+`","image":"`+image+`","name":"`+name+`","left":"`+strconv.Itoa(left)+`","top":"`+strconv.Itoa(top)+`","ram":"`+strconv.Itoa(ram)+`","console":"`+console+`","cpu":"`+strconv.Itoa(cpu)+`","cpulimit":"`+cpuLimit+`","firstmac":"`+firstMac+`","ethernet":"`+strconv.Itoa(ethernet)+`","rdp_user":"`+rdpUser+`","rdp_password":"`+rdpPassword+`","uuid":"`+uuid+`","count":"`+strconv.Itoa(count)+`"}`, nil, nil)
+	response, err := c.request("POST", endpointPath+"labs/"+labPath.api()+"/nodes", `{"path":"`+labPath.String()+`","type":"`+nodeType+`","template":"`+template+`","config":"`+config+`","delay":"`+strconv.Itoa(delay)+`","icon":"`+icon+`","image":"`+image+`","name":"`+name+`","left":"`+strconv.Itoa(left)+`","top":"`+strconv.Itoa(top)+`","ram":"`+strconv.Itoa(ram)+`","console":"`+console+`","cpu":"`+strconv.Itoa(cpu)+`","cpulimit":"`+cpuLimit+`","firstmac":"`+firstMac+`","ethernet":"`+strconv.Itoa(ethernet)+`","rdp_user":"`+rdpUser+`","rdp_password":"`+rdpPassword+`","uuid":"`+uuid+`","count":"`+strconv.Itoa(count)+`"}`, nil, nil)
 	if err != nil {
-		return 0, errors.Wrap(err, "error during http get request")
+		return 0, errors.Wrap(err, "error while adding node "+name+" to lab "+labPath.String())
 	}
 
 	var createResponse CreateResponse
@@ -228,29 +242,64 @@ func (c *EveNgClient) AddNode(labPath string, nodeType string, template string,
 /*
 RemoveNode removes a node from a lab
 */
-func (c *EveNgClient) RemoveNode(labPath string, nodeID int) error {
+func (c *EveNgClient) RemoveNode(labPath LabPath, nodeID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
 
-	_, err := c.request("DELETE", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID), "", nil, nil)
+	_, err := c.request("DELETE", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID), "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "Error during http request")
+		return errors.Wrap(err, "error while removing node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 
 	return nil
 }
 
+/*
+EditNode edits an existing node. All editable fields (name, image, icon, console, ram, cpu, ethernet, delay,
+position, config and first mac) are sent, so node should be retrieved with GetNode and modified.
+*/
+func (c *EveNgClient) EditNode(labPath LabPath, nodeID int, node Node) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	httpBody := map[string]string{
+		"name":     node.Name,
+		"image":    node.Image,
+		"icon":     node.Icon,
+		"console":  node.Console,
+		"ram":      strconv.Itoa(node.RAM),
+		"cpu":      strconv.Itoa(node.CPU),
+		"ethernet": strconv.Itoa(node.Ethernet),
+		"delay":    strconv.Itoa(node.Delay),
+		"top":      strconv.Itoa(node.Top),
+		"left":     strconv.Itoa(node.Left),
+		"config":   node.Config,
+	}
+	if node.Firstmac != "" {
+		httpBody["firstmac"] = node.Firstmac
+	}
+	b, err := json.Marshal(httpBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal http body to json")
+	}
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID), string(b), nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while editing node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
 /*
 GetNodes returns all nodes in a lab
 */
-func (c *EveNgClient) GetNodes(labPath string) (Nodes, error) {
+func (c *EveNgClient) GetNodes(labPath LabPath) (Nodes, error) {
 	if !c.isValid() {
 		return nil, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes", "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving nodes of lab "+labPath.String())
 	}
 	var nodes Nodes
 	err = c.unmarshalDataIntoStruct(response.Body(), &nodes)
@@ -263,13 +312,13 @@ func (c *EveNgClient) GetNodes(labPath string) (Nodes, error) {
 /*
 GetNode - Returns data for a specific lab node
 */
-func (c *EveNgClient) GetNode(labPath string, nodeID int) (Node, error) {
+func (c *EveNgClient) GetNode(labPath LabPath, nodeID int) (Node, error) {
 	if !c.isValid() {
 		return Node{}, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID), "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID), "", nil, nil)
 	if err != nil {
-		return Node{}, errors.Wrap(err, "error during http get request")
+		return Node{}, errors.Wrap(err, "error while retrieving node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	var node Node
 	err = c.unmarshalDataIntoStruct(response.Body(), &node)
@@ -282,16 +331,16 @@ func (c *EveNgClient) GetNode(labPath string, nodeID int) (Node, error) {
 /*
 StartNodes starts all nodes in a lab
 */
-func (c *EveNgClient) StartNodes(labPath string) error {
+func (c *EveNgClient) StartNodes(labPath LabPath) error {
 	nodes, err := c.GetNodes(labPath)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while starting nodes of lab "+labPath.String())
 	}
 
 	for _, node := range nodes {
 		err = c.StartNode(labPath, node.ID)
 		if err != nil {
-			return errors.Wrap(err, "error during http get request")
+			return errors.Wrap(err, "error while starting nodes of lab "+labPath.String())
 		}
 	}
 
@@ -301,13 +350,13 @@ func (c *EveNgClient) StartNodes(labPath string) error {
 /*
 StartNode starts a specific node in a lab
 */
-func (c *EveNgClient) StartNode(labPath string, nodeID int) error {
+func (c *EveNgClient) StartNode(labPath LabPath, nodeID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/start", "", nil, nil)
+	_, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/start", "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while starting node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	return nil
 }
@@ -315,16 +364,16 @@ func (c *EveNgClient) StartNode(labPath string, nodeID int) error {
 /*
 StopNodes stops all nodes in a lab
 */
-func (c *EveNgClient) StopNodes(labPath string) error {
+func (c *EveNgClient) StopNodes(labPath LabPath) error {
 	nodes, err := c.GetNodes(labPath)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while stopping nodes of lab "+labPath.String())
 	}
 
 	for _, node := range nodes {
 		err = c.StopNode(labPath, node.ID)
 		if err != nil {
-			return errors.Wrap(err, "error during http get request")
+			return errors.Wrap(err, "error while stopping nodes of lab "+labPath.String())
 		}
 	}
 
@@ -334,13 +383,13 @@ func (c *EveNgClient) StopNodes(labPath string) error {
 /*
 StopNode stops a specific node in a lab
 */
-func (c *EveNgClient) StopNode(labPath string, nodeID int) error {
+func (c *EveNgClient) StopNode(labPath LabPath, nodeID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/stop/stopmode=3", "", nil, nil)
+	_, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/stop/stopmode=3", "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while stopping node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	return nil
 }
@@ -348,10 +397,10 @@ func (c *EveNgClient) StopNode(labPath string, nodeID int) error {
 /*
 WipeNodes wipes all nodes in a lab
 */
-func (c *EveNgClient) WipeNodes(labPath string) error {
+func (c *EveNgClient) WipeNodes(labPath LabPath) error {
 	nodes, err := c.GetNodes(labPath)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while wiping nodes of lab "+labPath.String())
 	}
 
 	for _, node := range nodes {
@@ -367,13 +416,13 @@ func (c *EveNgClient) WipeNodes(labPath string) error {
 /*
 WipeNode wipes a specific node in a lab
 */
-func (c *EveNgClient) WipeNode(labPath string, nodeID int) error {
+func (c *EveNgClient) WipeNode(labPath LabPath, nodeID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/wipe", "", nil, nil)
+	_, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/wipe", "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while wiping node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	return err
 }
@@ -381,10 +430,10 @@ func (c *EveNgClient) WipeNode(labPath string, nodeID int) error {
 /*
 ExportNodes exports all nodes in a lab
 */
-func (c *EveNgClient) ExportNodes(labPath string) error {
+func (c *EveNgClient) ExportNodes(labPath LabPath) error {
 	nodes, err := c.GetNodes(labPath)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while exporting nodes of lab "+labPath.String())
 	}
 
 	for _, node := range nodes {
@@ -400,13 +449,13 @@ func (c *EveNgClient) ExportNodes(labPath string) error {
 /*
 ExportNode exports a specific node in a lab
 */
-func (c *EveNgClient) ExportNode(labPath string, nodeID int) error {
+func (c *EveNgClient) ExportNode(labPath LabPath, nodeID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("PUT", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/export", "", nil, nil)
+	_, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/export", "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while exporting node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 
 	return err
@@ -416,7 +465,7 @@ func (c *EveNgClient) ExportNode(labPath string, nodeID int) error {
 SetNodeStartupConfig sets a startup config for a given node. The startup config is passed as a path to a
 local startup config file.
 */
-func (c *EveNgClient) SetNodeStartupConfig(labPath string, nodeID int, startupConfigFilePath string) error {
+func (c *EveNgClient) SetNodeStartupConfig(labPath LabPath, nodeID int, startupConfigFilePath string) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
@@ -432,7 +481,7 @@ func (c *EveNgClient) SetNodeStartupConfig(labPath string, nodeID int, startupCo
 /*
 SetNodeStartupConfigString sets a startup config for a given node. The startup config is passed as a string.
 */
-func (c *EveNgClient) SetNodeStartupConfigString(labPath string, nodeID int, startupConfigString string) error {
+func (c *EveNgClient) SetNodeStartupConfigString(labPath LabPath, nodeID int, startupConfigString string) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
@@ -446,9 +495,9 @@ func (c *EveNgClient) SetNodeStartupConfigString(labPath string, nodeID int, sta
 		return errors.Wrap(err, "failed to marshal http body to json")
 	}
 
-	_, err = c.request("PUT", endpointPath+"labs/"+labPath+"/configs/"+strconv.Itoa(nodeID), string(b), nil, nil)
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/configs/"+strconv.Itoa(nodeID), string(b), nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http request")
+		return errors.Wrap(err, "error while setting startup config of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	return nil
 }
@@ -458,13 +507,13 @@ func (c *EveNgClient) SetNodeStartupConfigString(labPath string, nodeID int, sta
 /*
 ConnectNodeInterfaceToNetwork connects the given node interface to a network
 */
-func (c *EveNgClient) ConnectNodeInterfaceToNetwork(labPath string, nodeID int, interfaceID int, networkID int) error {
+func (c *EveNgClient) ConnectNodeInterfaceToNetwork(labPath LabPath, nodeID int, interfaceID int, networkID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("PUT", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", `{"`+strconv.Itoa(interfaceID)+`":"`+strconv.Itoa(networkID)+`"}`, nil, nil)
+	_, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", `{"`+strconv.Itoa(interfaceID)+`":"`+strconv.Itoa(networkID)+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while connecting interface "+strconv.Itoa(interfaceID)+" of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String()+" to network "+strconv.Itoa(networkID))
 	}
 
 	return nil
@@ -473,13 +522,13 @@ func (c *EveNgClient) ConnectNodeInterfaceToNetwork(labPath string, nodeID int,
 /*
 DisconnectNodeInterfaceFromNetwork disconnects the given node interface to a network
 */
-func (c *EveNgClient) DisconnectNodeInterfaceFromNetwork(labPath string, nodeID int, interfaceID int) error {
+func (c *EveNgClient) DisconnectNodeInterfaceFromNetwork(labPath LabPath, nodeID int, interfaceID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("PUT", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", `{"`+strconv.Itoa(interfaceID)+`":""}`, nil, nil)
+	_, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", `{"`+strconv.Itoa(interfaceID)+`":""}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while disconnecting interface "+strconv.Itoa(interfaceID)+" of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 
 	return nil
@@ -488,13 +537,13 @@ func (c *EveNgClient) DisconnectNodeInterfaceFromNetwork(labPath string, nodeID
 /*
 GetNodeInterfaces returns all interfaces for a specific lab node
 */
-func (c *EveNgClient) GetNodeInterfaces(labPath string, nodeID int) (Interfaces, error) {
+func (c *EveNgClient) GetNodeInterfaces(labPath LabPath, nodeID int) (Interfaces, error) {
 	if !c.isValid() {
 		return Interfaces{}, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", "", nil, nil)
 	if err != nil {
-		return Interfaces{}, errors.Wrap(err, "error during http get request")
+		return Interfaces{}, errors.Wrap(err, "error while retrieving interfaces of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
 	}
 	var interfaces Interfaces
 	err = c.unmarshalDataIntoStruct(response.Body(), &interfaces)
@@ -504,6 +553,149 @@ func (c *EveNgClient) GetNodeInterfaces(labPath string, nodeID int) (Interfaces,
 	return interfaces, nil
 }
 
+/*
+GetNodeInterface returns the ethernet or serial interface of a lab node matching the given name
+*/
+func (c *EveNgClient) GetNodeInterface(labPath LabPath, nodeID int, interfaceName string) (Interface, InterfaceType, error) {
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return Interface{}, "", errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	iface, interfaceType, err := interfaces.FindInterface(interfaceName)
+	if err != nil {
+		return Interface{}, "", errors.Wrap(err, "node "+strconv.Itoa(nodeID))
+	}
+	return iface, interfaceType, nil
+}
+
+/*
+ConnectNodeInterfaceToNetworkByName connects the ethernet interface with the given name to a network
+*/
+func (c *EveNgClient) ConnectNodeInterfaceToNetworkByName(labPath LabPath, nodeID int, interfaceName string, networkID int) error {
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	iface, err := interfaces.FindInterfaceOfType(interfaceName, InterfaceTypeEthernet)
+	if err != nil {
+		return errors.Wrap(err, "node "+strconv.Itoa(nodeID))
+	}
+	return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, iface.ID, networkID)
+}
+
+/*
+ConnectNodeSerialInterfaces connects the serial interface with the given name to a serial interface of another node
+*/
+func (c *EveNgClient) ConnectNodeSerialInterfaces(labPath LabPath, nodeID int, interfaceName string, remoteNodeID int, remoteInterfaceName string) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	iface, err := interfaces.FindInterfaceOfType(interfaceName, InterfaceTypeSerial)
+	if err != nil {
+		return errors.Wrap(err, "node "+strconv.Itoa(nodeID))
+	}
+	remoteInterfaces, err := c.GetNodeInterfaces(labPath, remoteNodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving remote node interfaces")
+	}
+	remoteIface, err := remoteInterfaces.FindInterfaceOfType(remoteInterfaceName, InterfaceTypeSerial)
+	if err != nil {
+		return errors.Wrap(err, "node "+strconv.Itoa(remoteNodeID))
+	}
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces", `{"`+strconv.Itoa(iface.ID)+`":"`+strconv.Itoa(remoteNodeID)+`:`+strconv.Itoa(remoteIface.ID)+`"}`, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while connecting serial interface "+interfaceName+" of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
+/*
+DisconnectNodeInterfaceByName disconnects the ethernet or serial interface with the given name
+*/
+func (c *EveNgClient) DisconnectNodeInterfaceByName(labPath LabPath, nodeID int, interfaceName string) error {
+	iface, _, err := c.GetNodeInterface(labPath, nodeID, interfaceName)
+	if err != nil {
+		return err
+	}
+	return c.DisconnectNodeInterfaceFromNetwork(labPath, nodeID, iface.ID)
+}
+
+//---------- Link quality operations ----------//
+
+/*
+GetLinkState returns the link quality settings and suspend state of a connected node interface
+*/
+func (c *EveNgClient) GetLinkState(labPath LabPath, nodeID int, interfaceID int) (LinkState, error) {
+	topology, err := c.GetTopology(labPath)
+	if err != nil {
+		return LinkState{}, errors.Wrap(err, "error while retrieving topology")
+	}
+	return findLinkState(topology, nodeID, interfaceID)
+}
+
+/*
+SetLinkImpairment changes delay, jitter, loss and bandwidth of a node interface, also on running labs. Link quality
+controls require eve-ng professional.
+*/
+func (c *EveNgClient) SetLinkImpairment(labPath LabPath, nodeID int, interfaceID int, impairment Impairment) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	err := impairment.Validate()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(impairment)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal http body to json")
+	}
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces/"+strconv.Itoa(interfaceID)+"/quality", string(b), nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while impairing link of interface "+strconv.Itoa(interfaceID)+" of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
+/*
+ClearLinkImpairment removes all impairments of a node interface
+*/
+func (c *EveNgClient) ClearLinkImpairment(labPath LabPath, nodeID int, interfaceID int) error {
+	return c.SetLinkImpairment(labPath, nodeID, interfaceID, Impairment{})
+}
+
+/*
+SuspendLink suspends the link of a node interface, packets are dropped until the link is resumed
+*/
+func (c *EveNgClient) SuspendLink(labPath LabPath, nodeID int, interfaceID int) error {
+	return c.setLinkSuspended(labPath, nodeID, interfaceID, true)
+}
+
+/*
+ResumeLink resumes a suspended link of a node interface
+*/
+func (c *EveNgClient) ResumeLink(labPath LabPath, nodeID int, interfaceID int) error {
+	return c.setLinkSuspended(labPath, nodeID, interfaceID, false)
+}
+
+func (c *EveNgClient) setLinkSuspended(labPath LabPath, nodeID int, interfaceID int, suspended bool) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	suspend := "0"
+	if suspended {
+		suspend = "1"
+	}
+	_, err := c.request("PUT", endpointPath+"labs/"+labPath.api()+"/nodes/"+strconv.Itoa(nodeID)+"/interfaces/"+strconv.Itoa(interfaceID)+"/quality", `{"suspend":`+suspend+`}`, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while changing link state of interface "+strconv.Itoa(interfaceID)+" of node "+strconv.Itoa(nodeID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
 //---------- Node Template operations ----------//
 
 /*
@@ -515,7 +707,7 @@ func (c *EveNgClient) GetNodeTemplates() (Templates, error) {
 	}
 	response, err := c.request("GET", endpointPath+"list/templates/", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving node templates")
 	}
 	var templates Templates
 	err = c.unmarshalDataIntoStruct(response.Body(), &templates)
@@ -534,7 +726,7 @@ func (c *EveNgClient) GetNodeTemplate(templateName string) (Template, error) {
 	}
 	response, err := c.request("GET", endpointPath+"list/templates/"+templateName, "", nil, nil)
 	if err != nil {
-		return Template{}, errors.Wrap(err, "error during http get request")
+		return Template{}, errors.Wrap(err, "error while retrieving node template "+templateName)
 	}
 	var template Template
 	err = c.unmarshalDataIntoStruct(response.Body(), &template)
@@ -549,13 +741,13 @@ func (c *EveNgClient) GetNodeTemplate(templateName string) (Template, error) {
 /*
 AddNetwork add a new network to a lab
 */
-func (c *EveNgClient) AddNetwork(labPath string, networkType string, networkName string, left int, top int, visibility int, postfix int) (int, error) {
+func (c *EveNgClient) AddNetwork(labPath LabPath, networkType string, networkName string, left int, top int, visibility int, postfix int) (int, error) {
 	if !c.isValid() {
 		return 0, &NotValidError{}
 	}
-	response, err := c.request("POST", endpointPath+"labs/"+labPath+"/networks", `{"type":"`+networkType+`","name":"`+networkName+`","left":"`+strconv.Itoa(left)+`","top":"`+strconv.Itoa(top)+`","visibility":"`+strconv.Itoa(visibility)+`","postfix":"`+strconv.Itoa(postfix)+`"}`, nil, nil)
+	response, err := c.request("POST", endpointPath+"labs/"+labPath.api()+"/networks", `{"type":"`+networkType+`","name":"`+networkName+`","left":"`+strconv.Itoa(left)+`","top":"`+strconv.Itoa(top)+`","visibility":"`+strconv.Itoa(visibility)+`","postfix":"`+strconv.Itoa(postfix)+`"}`, nil, nil)
 	if err != nil {
-		return 0, errors.Wrap(err, "error during http get request")
+		return 0, errors.Wrap(err, "error while adding network "+networkName+" to lab "+labPath.String())
 	}
 
 	var createResponse CreateResponse
@@ -570,14 +762,14 @@ func (c *EveNgClient) AddNetwork(labPath string, networkType string, networkName
 /*
 RemoveNetwork removes a given network
 */
-func (c *EveNgClient) RemoveNetwork(labPath string, networkID int) error {
+func (c *EveNgClient) RemoveNetwork(labPath LabPath, networkID int) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
 
-	_, err := c.request("DELETE", endpointPath+"labs/"+labPath+"/networks/"+strconv.Itoa(networkID), "", nil, nil)
+	_, err := c.request("DELETE", endpointPath+"labs/"+labPath.api()+"/networks/"+strconv.Itoa(networkID), "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "Error during http delete request")
+		return errors.Wrap(err, "error while removing network "+strconv.Itoa(networkID)+" of lab "+labPath.String())
 	}
 
 	return nil
@@ -586,13 +778,13 @@ func (c *EveNgClient) RemoveNetwork(labPath string, networkID int) error {
 /*
 GetNetworks returns a list of all networks configured in a lab
 */
-func (c *EveNgClient) GetNetworks(labPath string) (Networks, error) {
+func (c *EveNgClient) GetNetworks(labPath LabPath) (Networks, error) {
 	if !c.isValid() {
 		return nil, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/networks", "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/networks", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving networks of lab "+labPath.String())
 	}
 	var networks Networks
 	err = c.unmarshalDataIntoStruct(response.Body(), &networks)
@@ -605,13 +797,13 @@ func (c *EveNgClient) GetNetworks(labPath string) (Networks, error) {
 /*
 GetNetwork returns data for given network id for lab
 */
-func (c *EveNgClient) GetNetwork(labPath string, networkID int) (Network, error) {
+func (c *EveNgClient) GetNetwork(labPath LabPath, networkID int) (Network, error) {
 	if !c.isValid() {
 		return Network{}, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"labs/"+labPath+"/networks/"+strconv.Itoa(networkID), "", nil, nil)
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/networks/"+strconv.Itoa(networkID), "", nil, nil)
 	if err != nil {
-		return Network{}, errors.Wrap(err, "error during http get request")
+		return Network{}, errors.Wrap(err, "error while retrieving network "+strconv.Itoa(networkID)+" of lab "+labPath.String())
 	}
 	var network Network
 	err = c.unmarshalDataIntoStruct(response.Body(), &network)
@@ -630,7 +822,7 @@ func (c *EveNgClient) GetNetworkTypes() (NetworkTypes, error) {
 	}
 	response, err := c.request("GET", endpointPath+"list/networks", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving network types")
 	}
 	var networkTypes NetworkTypes
 	err = c.unmarshalDataIntoStruct(response.Body(), &networkTypes)
@@ -640,6 +832,266 @@ func (c *EveNgClient) GetNetworkTypes() (NetworkTypes, error) {
 	return networkTypes, nil
 }
 
+//---------- Picture operations ----------//
+
+/*
+GetPictures returns all pictures of a lab
+*/
+func (c *EveNgClient) GetPictures(labPath LabPath) (Pictures, error) {
+	if !c.isValid() {
+		return nil, &NotValidError{}
+	}
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/pictures", "", nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving pictures of lab "+labPath.String())
+	}
+	var pictures Pictures
+	err = c.unmarshalDataIntoStruct(response.Body(), &pictures)
+	if err != nil {
+		return nil, err
+	}
+	return pictures, nil
+}
+
+/*
+GetPicture returns data for the given picture including its image map
+*/
+func (c *EveNgClient) GetPicture(labPath LabPath, pictureID int) (Picture, error) {
+	if !c.isValid() {
+		return Picture{}, &NotValidError{}
+	}
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/pictures/"+strconv.Itoa(pictureID), "", nil, nil)
+	if err != nil {
+		return Picture{}, errors.Wrap(err, "error while retrieving picture "+strconv.Itoa(pictureID)+" of lab "+labPath.String())
+	}
+	var picture Picture
+	err = c.unmarshalDataIntoStruct(response.Body(), &picture)
+	if err != nil {
+		return Picture{}, err
+	}
+	return picture, nil
+}
+
+/*
+DownloadPictureData returns the image of the given picture
+*/
+func (c *EveNgClient) DownloadPictureData(labPath LabPath, pictureID int) ([]byte, error) {
+	if !c.isValid() {
+		return nil, &NotValidError{}
+	}
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/pictures/"+strconv.Itoa(pictureID)+"/data", "", nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while downloading picture "+strconv.Itoa(pictureID)+" of lab "+labPath.String())
+	}
+	return response.Body(), nil
+}
+
+/*
+AddPicture uploads a png or jpeg image as new picture of a lab. The image map can be built with BuildImageMap.
+*/
+func (c *EveNgClient) AddPicture(labPath LabPath, name string, imageMap string, image []byte) (int, error) {
+	if !c.isValid() {
+		return 0, &NotValidError{}
+	}
+	contentType, err := pictureContentType(image)
+	if err != nil {
+		return 0, err
+	}
+	fileName := name + ".png"
+	if contentType == "image/jpeg" {
+		fileName = name + ".jpg"
+	}
+	response, err := c.upload(endpointPath+"labs/"+labPath.api()+"/pictures", map[string]string{"name": name, "map": imageMap}, "file", fileName, contentType, image)
+	if err != nil {
+		return 0, errors.Wrap(err, "error while adding picture "+name+" to lab "+labPath.String())
+	}
+
+	var createResponse CreateResponse
+	err = c.unmarshalDataIntoStruct(response.Body(), &createResponse)
+	if err != nil {
+		return 0, err
+	}
+	return createResponse.ID, nil
+}
+
+/*
+EditPicture changes name and image map of an existing picture
+*/
+func (c *EveNgClient) EditPicture(labPath LabPath, pictureID int, name string, imageMap string) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	httpBody := make(map[string]string)
+	httpBody["name"] = name
+	httpBody["map"] = imageMap
+
+	b, err := json.Marshal(httpBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal http body to json")
+	}
+
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/pictures/"+strconv.Itoa(pictureID), string(b), nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while editing picture "+strconv.Itoa(pictureID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
+/*
+RemovePicture removes a picture from a lab
+*/
+func (c *EveNgClient) RemovePicture(labPath LabPath, pictureID int) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	_, err := c.request("DELETE", endpointPath+"labs/"+labPath.api()+"/pictures/"+strconv.Itoa(pictureID), "", nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while removing picture "+strconv.Itoa(pictureID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
+//---------- Text object operations ----------//
+
+/*
+GetTextObjects returns all text objects of a lab
+*/
+func (c *EveNgClient) GetTextObjects(labPath LabPath) (TextObjects, error) {
+	if !c.isValid() {
+		return nil, &NotValidError{}
+	}
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/textobjects", "", nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving text objects of lab "+labPath.String())
+	}
+	var textObjects TextObjects
+	err = c.unmarshalDataIntoStruct(response.Body(), &textObjects)
+	if err != nil {
+		return nil, err
+	}
+	return textObjects, nil
+}
+
+/*
+GetTextObject returns data for the given text object
+*/
+func (c *EveNgClient) GetTextObject(labPath LabPath, textObjectID int) (TextObject, error) {
+	if !c.isValid() {
+		return TextObject{}, &NotValidError{}
+	}
+	response, err := c.request("GET", endpointPath+"labs/"+labPath.api()+"/textobjects/"+strconv.Itoa(textObjectID), "", nil, nil)
+	if err != nil {
+		return TextObject{}, errors.Wrap(err, "error while retrieving text object "+strconv.Itoa(textObjectID)+" of lab "+labPath.String())
+	}
+	var textObject TextObject
+	err = c.unmarshalDataIntoStruct(response.Body(), &textObject)
+	if err != nil {
+		return TextObject{}, err
+	}
+	if textObject.ID == 0 {
+		textObject.ID = textObjectID
+	}
+	return textObject, nil
+}
+
+/*
+AddTextObject adds a text object with the given html data to a lab
+*/
+func (c *EveNgClient) AddTextObject(labPath LabPath, name string, objectType string, data string) (int, error) {
+	if !c.isValid() {
+		return 0, &NotValidError{}
+	}
+	httpBody := make(map[string]string)
+	httpBody["name"] = name
+	httpBody["type"] = objectType
+	httpBody["data"] = data
+
+	b, err := json.Marshal(httpBody)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal http body to json")
+	}
+
+	response, err := c.request("POST", endpointPath+"labs/"+labPath.api()+"/textobjects", string(b), nil, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "error while adding text object "+name+" to lab "+labPath.String())
+	}
+
+	var createResponse CreateResponse
+	err = c.unmarshalDataIntoStruct(response.Body(), &createResponse)
+	if err != nil {
+		return 0, err
+	}
+	return createResponse.ID, nil
+}
+
+/*
+AddTextShape adds a label, rectangle or circle to a lab
+*/
+func (c *EveNgClient) AddTextShape(labPath LabPath, name string, shape TextShape) (int, error) {
+	textObjects, err := c.GetTextObjects(labPath)
+	if err != nil {
+		return 0, errors.Wrap(err, "error while retrieving text objects")
+	}
+	// the web interface embeds the id of the text object into its html, so the next id is used for the html
+	nextID := 1
+	for _, textObject := range textObjects {
+		if textObject.ID >= nextID {
+			nextID = textObject.ID + 1
+		}
+	}
+	id, err := c.AddTextObject(labPath, name, shape.objectType(), shape.HTML(nextID))
+	if err != nil {
+		return 0, err
+	}
+	if id != 0 && id != nextID {
+		err = c.EditTextObject(labPath, id, name, shape.HTML(id))
+		if err != nil {
+			return id, errors.Wrap(err, "error while updating text object html")
+		}
+	}
+	if id == 0 {
+		id = nextID
+	}
+	return id, nil
+}
+
+/*
+EditTextObject changes name and html data of an existing text object
+*/
+func (c *EveNgClient) EditTextObject(labPath LabPath, textObjectID int, name string, data string) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	httpBody := make(map[string]string)
+	httpBody["name"] = name
+	httpBody["data"] = data
+
+	b, err := json.Marshal(httpBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal http body to json")
+	}
+
+	_, err = c.request("PUT", endpointPath+"labs/"+labPath.api()+"/textobjects/"+strconv.Itoa(textObjectID), string(b), nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while editing text object "+strconv.Itoa(textObjectID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
+/*
+RemoveTextObject removes a text object from a lab
+*/
+func (c *EveNgClient) RemoveTextObject(labPath LabPath, textObjectID int) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	_, err := c.request("DELETE", endpointPath+"labs/"+labPath.api()+"/textobjects/"+strconv.Itoa(textObjectID), "", nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "error while removing text object "+strconv.Itoa(textObjectID)+" of lab "+labPath.String())
+	}
+	return nil
+}
+
 //---------- User operations ----------//
 
 /*
@@ -651,7 +1103,7 @@ func (c *EveNgClient) AddUser(username string, name string, email string, passwo
 	}
 	_, err := c.request("POST", endpointPath+"users", `{"username":"`+username+`","name":"`+name+`","email":"`+email+`","password":"`+password+`","role":"`+role+`","expiration":"`+expiration+`","datestart":"`+dateStart+`","extauth":"`+extAuth+`","pod":`+strconv.Itoa(pod)+`,"pexpiration":"`+pexpiration+`","cpu":`+strconv.Itoa(cpu)+`,"ram":`+strconv.Itoa(ram)+`}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while adding user "+username)
 	}
 	return nil
 }
@@ -665,7 +1117,7 @@ func (c *EveNgClient) RemoveUser(username string) error {
 	}
 	_, err := c.request("DELETE", endpointPath+"users/"+username, "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while removing user "+username)
 	}
 	return nil
 }
@@ -679,7 +1131,7 @@ func (c *EveNgClient) EditUser(username string, name string, email string, passw
 	}
 	_, err := c.request("PUT", endpointPath+"users/"+username, `{"name":"`+name+`","email":"`+email+`","password":"`+password+`","role":"`+role+`","expiration":"`+expiration+`","pod":`+strconv.Itoa(pod)+`,"pexpiration":"`+pexpiration+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while editing user "+username)
 	}
 	return nil
 }
@@ -693,7 +1145,7 @@ func (c *EveNgClient) GetUsers() (Users, error) {
 	}
 	response, err := c.request("GET", endpointPath+"users/", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving users")
 	}
 	var users Users
 	err = c.unmarshalDataIntoStruct(response.Body(), &users)
@@ -712,7 +1164,7 @@ func (c *EveNgClient) GetUser(username string) (User, error) {
 	}
 	response, err := c.request("GET", endpointPath+"users/"+username, "", nil, nil)
 	if err != nil {
-		return User{}, errors.Wrap(err, "error during http get request")
+		return User{}, errors.Wrap(err, "error while retrieving user "+username)
 	}
 	var user User
 	err = c.unmarshalDataIntoStruct(response.Body(), &user)
@@ -731,7 +1183,7 @@ func (c *EveNgClient) GetUserRoles() (UserRoles, error) {
 	}
 	response, err := c.request("GET", endpointPath+"list/roles", "", nil, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error during http get request")
+		return nil, errors.Wrap(err, "error while retrieving user roles")
 	}
 	var userRoles UserRoles
 	err = c.unmarshalDataIntoStruct(response.Body(), &userRoles)
@@ -746,13 +1198,13 @@ func (c *EveNgClient) GetUserRoles() (UserRoles, error) {
 /*
 AddFolder adds a new folder to the given directory
 */
-func (c *EveNgClient) AddFolder(path string, folderName string) error {
+func (c *EveNgClient) AddFolder(path FolderPath, folderName string) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("POST", endpointPath+"folders", `{"path":"`+path+`","name":"`+folderName+`"}`, nil, nil)
+	_, err := c.request("POST", endpointPath+"folders", `{"path":"`+path.String()+`","name":"`+folderName+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while adding folder "+path.Join(folderName).String())
 	}
 	return nil
 }
@@ -760,13 +1212,13 @@ func (c *EveNgClient) AddFolder(path string, folderName string) error {
 /*
 MoveFolder moves/renames an existing folder
 */
-func (c *EveNgClient) MoveFolder(oldPath string, newPath string) error {
+func (c *EveNgClient) MoveFolder(oldPath FolderPath, newPath FolderPath) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("PUT", endpointPath+"folders/"+oldPath, `{"path":"`+newPath+`"}`, nil, nil)
+	_, err := c.request("PUT", endpointPath+"folders/"+oldPath.api(), `{"path":"`+newPath.String()+`"}`, nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while moving folder "+oldPath.String()+" to "+newPath.String())
 	}
 	return nil
 }
@@ -774,13 +1226,13 @@ func (c *EveNgClient) MoveFolder(oldPath string, newPath string) error {
 /*
 RemoveFolder deletes an existing folder
 */
-func (c *EveNgClient) RemoveFolder(path string) error {
+func (c *EveNgClient) RemoveFolder(path FolderPath) error {
 	if !c.isValid() {
 		return &NotValidError{}
 	}
-	_, err := c.request("DELETE", endpointPath+"folders/"+path, "", nil, nil)
+	_, err := c.request("DELETE", endpointPath+"folders/"+path.api(), "", nil, nil)
 	if err != nil {
-		return errors.Wrap(err, "error during http get request")
+		return errors.Wrap(err, "error while removing folder "+path.String())
 	}
 	return nil
 }
@@ -788,13 +1240,13 @@ func (c *EveNgClient) RemoveFolder(path string) error {
 /*
 GetFolderContents returns contents of a given folder
 */
-func (c *EveNgClient) getFolderContents(folder string) (FolderContents, error) {
+func (c *EveNgClient) getFolderContents(folder FolderPath) (FolderContents, error) {
 	if !c.isValid() {
 		return FolderContents{}, &NotValidError{}
 	}
-	response, err := c.request("GET", endpointPath+"folders/"+folder, "", nil, nil)
+	response, err := c.request("GET", endpointPath+"folders/"+folder.api(), "", nil, nil)
 	if err != nil {
-		return FolderContents{}, errors.Wrap(err, "error during http get request")
+		return FolderContents{}, errors.Wrap(err, "error while listing folder "+folder.String())
 	}
 	var folderContents FolderContents
 	err = c.unmarshalDataIntoStruct(response.Body(), &folderContents)
@@ -807,7 +1259,7 @@ func (c *EveNgClient) getFolderContents(folder string) (FolderContents, error) {
 /*
 GetLabFiles returns all lab files in a given path
 */
-func (c *EveNgClient) GetLabFiles(path string) (LabFiles, error) {
+func (c *EveNgClient) GetLabFiles(path FolderPath) (LabFiles, error) {
 	folderContents, err := c.getFolderContents(path)
 	if err != nil {
 		return LabFiles{}, errors.Wrap(err, "error while retrieving lab files for given path")
@@ -819,7 +1271,7 @@ func (c *EveNgClient) GetLabFiles(path string) (LabFiles, error) {
 /*
 GetFolders returns all folders in a given path
 */
-func (c *EveNgClient) GetFolders(path string) (Folders, error) {
+func (c *EveNgClient) GetFolders(path FolderPath) (Folders, error) {
 	folderContents, err := c.getFolderContents(path)
 	if err != nil {
 		return Folders{}, errors.Wrap(err, "error while retrieving folder for given path")
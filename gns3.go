@@ -0,0 +1,446 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+GNS3Project contains a gns3 project as stored in a .gns3 file
+*/
+type GNS3Project struct {
+	Name      string       `json:"name"`
+	ProjectID string       `json:"project_id"`
+	Revision  int          `json:"revision"`
+	Version   string       `json:"version"`
+	Topology  GNS3Topology `json:"topology"`
+}
+
+/*
+GNS3Topology contains the nodes, links and drawings of a gns3 project
+*/
+type GNS3Topology struct {
+	Nodes    []GNS3Node    `json:"nodes"`
+	Links    []GNS3Link    `json:"links"`
+	Drawings []GNS3Drawing `json:"drawings"`
+}
+
+/*
+GNS3Node is a node of a gns3 project
+*/
+type GNS3Node struct {
+	NodeID      string                 `json:"node_id"`
+	Name        string                 `json:"name"`
+	NodeType    string                 `json:"node_type"`
+	TemplateID  string                 `json:"template_id"`
+	ApplianceID string                 `json:"appliance_id"`
+	Symbol      string                 `json:"symbol"`
+	X           int                    `json:"x"`
+	Y           int                    `json:"y"`
+	Ports       []GNS3Port             `json:"ports"`
+	Properties  map[string]interface{} `json:"properties"`
+}
+
+/*
+GNS3Port is a port of a gns3 node
+*/
+type GNS3Port struct {
+	Name          string `json:"name"`
+	ShortName     string `json:"short_name"`
+	AdapterNumber int    `json:"adapter_number"`
+	PortNumber    int    `json:"port_number"`
+	LinkType      string `json:"link_type"`
+}
+
+/*
+GNS3Link is a point to point link between two gns3 node ports
+*/
+type GNS3Link struct {
+	LinkID string         `json:"link_id"`
+	Nodes  []GNS3LinkNode `json:"nodes"`
+}
+
+/*
+GNS3LinkNode is one end of a gns3 link
+*/
+type GNS3LinkNode struct {
+	NodeID        string `json:"node_id"`
+	AdapterNumber int    `json:"adapter_number"`
+	PortNumber    int    `json:"port_number"`
+}
+
+/*
+GNS3Drawing is a svg drawing of a gns3 project
+*/
+type GNS3Drawing struct {
+	DrawingID string `json:"drawing_id"`
+	SVG       string `json:"svg"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Z         int    `json:"z"`
+	Rotation  int    `json:"rotation"`
+}
+
+/*
+GNS3ImportReport describes what an import of a gns3 project created or would create in dry run mode. In dry run
+mode all ids are placeholders.
+*/
+type GNS3ImportReport struct {
+	LabPath       LabPath
+	DryRun        bool
+	NodeIDs       map[string]int
+	NetworkIDs    map[string]int
+	Actions       []string
+	UnmappedNodes []string
+	SkippedLinks  []string
+	Warnings      []string
+}
+
+// gns3NetworkTypes maps builtin gns3 node types which are represented as networks in eve-ng
+var gns3NetworkTypes = map[string]string{
+	"ethernet_switch":    "bridge",
+	"ethernet_hub":       "bridge",
+	"frame_relay_switch": "bridge",
+	"atm_switch":         "bridge",
+	"nat":                "nat0",
+	"cloud":              "pnet0",
+}
+
+/*
+ReadGNS3ProjectFile reads and parses a .gns3 file
+*/
+func ReadGNS3ProjectFile(filename string) (*GNS3Project, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading file")
+	}
+	project, err := ParseGNS3Project(b)
+	if err != nil {
+		return nil, err
+	}
+	if project.Name == "" {
+		project.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	return project, nil
+}
+
+/*
+ParseGNS3Project parses the content of a .gns3 file
+*/
+func ParseGNS3Project(data []byte) (*GNS3Project, error) {
+	var project GNS3Project
+	err := json.Unmarshal(data, &project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal gns3 project")
+	}
+	return &project, nil
+}
+
+/*
+MappingKeys returns the keys which are tried in order to find the mapping of a node: template id, appliance id,
+image and node type
+*/
+func (n GNS3Node) MappingKeys() []string {
+	var keys []string
+	for _, key := range []string{n.TemplateID, n.ApplianceID, n.image(), n.NodeType} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+/*
+ImportGNS3Project creates an eve-ng lab in the given folder which is equivalent to the given gns3 project. Nodes
+are mapped to eve-ng templates by the keys returned by GNS3Node.MappingKeys, switches, hubs, clouds and nat nodes
+become eve-ng networks. With dryRun set nothing is created and the report lists what would be done.
+*/
+func (c *EveNgClient) ImportGNS3Project(folder FolderPath, project *GNS3Project, mappings KindMappings, dryRun bool) (GNS3ImportReport, error) {
+	report := GNS3ImportReport{
+		LabPath:    folder.Lab(project.Name),
+		DryRun:     dryRun,
+		NodeIDs:    make(map[string]int),
+		NetworkIDs: make(map[string]int),
+	}
+	if !dryRun && !c.isValid() {
+		return report, &NotValidError{}
+	}
+
+	report.Actions = append(report.Actions, "add lab "+report.LabPath.String())
+	if !dryRun {
+		err := c.AddLab(folder, project.Name, "1", "", "Imported from gns3", "")
+		if err != nil {
+			return report, errors.Wrap(err, "error while adding lab")
+		}
+	}
+
+	minX, minY := 0, 0
+	for _, node := range project.Topology.Nodes {
+		if node.X < minX {
+			minX = node.X
+		}
+		if node.Y < minY {
+			minY = node.Y
+		}
+	}
+	position := func(node GNS3Node) (int, int) {
+		return node.X - minX + 50, node.Y - minY + 50
+	}
+
+	nodesByID := make(map[string]GNS3Node)
+	nodeMappings := make(map[string]KindMapping)
+	networkIDs := make(map[string]int)
+	for _, node := range project.Topology.Nodes {
+		nodesByID[node.NodeID] = node
+		left, top := position(node)
+
+		if networkType, ok := gns3NetworkTypes[node.NodeType]; ok {
+			report.Actions = append(report.Actions, "add "+networkType+" network "+node.Name)
+			networkID := len(report.NetworkIDs) + 1
+			if !dryRun {
+				var err error
+				networkID, err = c.AddNetwork(report.LabPath, networkType, node.Name, left, top, 1, 0)
+				if err != nil {
+					return report, errors.Wrap(err, "error while adding network "+node.Name)
+				}
+			}
+			report.NetworkIDs[node.Name] = networkID
+			networkIDs[node.NodeID] = networkID
+			continue
+		}
+
+		mapping, ok := KindMapping{}, false
+		for _, key := range node.MappingKeys() {
+			if mapping, ok = mappings[key]; ok {
+				break
+			}
+		}
+		if !ok {
+			report.UnmappedNodes = append(report.UnmappedNodes, node.Name+" (tried "+strings.Join(node.MappingKeys(), ", ")+")")
+			continue
+		}
+		nodeMappings[node.NodeID] = mapping
+		report.Actions = append(report.Actions, "add node "+node.Name+" using template "+mapping.Template)
+		nodeID := len(report.NodeIDs) + 1
+		if !dryRun {
+			var err error
+			nodeID, err = c.AddNode(report.LabPath, mapping.NodeType, mapping.Template, "0", 0, mapping.Icon, mapping.Image, node.Name, left, top, mapping.RAM, mapping.Console, mapping.CPU, "undefined", mapping.Ethernet, "", "", "", "", 1)
+			if err != nil {
+				return report, errors.Wrap(err, "error while adding node "+node.Name)
+			}
+		}
+		report.NodeIDs[node.Name] = nodeID
+	}
+
+	for _, link := range project.Topology.Links {
+		if len(link.Nodes) != 2 {
+			report.SkippedLinks = append(report.SkippedLinks, link.LinkID+" (link needs exactly two endpoints)")
+			continue
+		}
+		var ends [2]gns3LinkEnd
+		for i, linkNode := range link.Nodes {
+			node := nodesByID[linkNode.NodeID]
+			ends[i] = gns3LinkEnd{node: node, port: node.port(linkNode)}
+			ends[i].networkID, ends[i].isNetwork = networkIDs[node.NodeID]
+			_, ends[i].isNode = nodeMappings[node.NodeID]
+		}
+		description := ends[0].String() + " <-> " + ends[1].String()
+		if ends[1].isNetwork {
+			ends[0], ends[1] = ends[1], ends[0]
+		}
+
+		switch {
+		case ends[0].isNetwork && ends[1].isNetwork:
+			report.SkippedLinks = append(report.SkippedLinks, description+" (links between switches or clouds are not supported)")
+			continue
+		case !ends[0].isNode && !ends[0].isNetwork || !ends[1].isNode:
+			report.SkippedLinks = append(report.SkippedLinks, description+" (node was not imported)")
+			continue
+		}
+
+		if ends[0].port.LinkType == "serial" || ends[1].port.LinkType == "serial" {
+			if ends[0].isNetwork {
+				// e.g. a frame relay switch, the serial interface of the node is connected to its network
+				report.Actions = append(report.Actions, "connect serial "+ends[1].String()+" to network "+strconv.Itoa(ends[0].networkID))
+			} else {
+				report.Actions = append(report.Actions, "connect serial link "+description)
+			}
+			if !dryRun {
+				var err error
+				if ends[0].isNetwork {
+					err = c.connectGNS3SerialPort(report.LabPath, report.NodeIDs[ends[1].node.Name], ends[1].port, ends[0].networkID)
+				} else {
+					err = c.ConnectNodeSerialInterfaces(report.LabPath, report.NodeIDs[ends[0].node.Name], ends[0].port.Name, report.NodeIDs[ends[1].node.Name], ends[1].port.Name)
+				}
+				if err != nil {
+					report.SkippedLinks = append(report.SkippedLinks, description+" ("+err.Error()+")")
+				}
+			}
+			continue
+		}
+
+		networkID := ends[0].networkID
+		toConnect := ends[1:]
+		if !ends[0].isNetwork {
+			report.Actions = append(report.Actions, "add point to point network for "+description)
+			networkID = len(report.NetworkIDs) + 1
+			if !dryRun {
+				leftA, topA := position(ends[0].node)
+				leftB, topB := position(ends[1].node)
+				var err error
+				networkID, err = c.AddNetwork(report.LabPath, "bridge", description, (leftA+leftB)/2, (topA+topB)/2, 0, 0)
+				if err != nil {
+					return report, errors.Wrap(err, "error while adding network for link "+description)
+				}
+			}
+			report.NetworkIDs[description] = networkID
+			toConnect = ends[:]
+		}
+
+		for _, end := range toConnect {
+			report.Actions = append(report.Actions, "connect "+end.String()+" to network "+strconv.Itoa(networkID))
+			if dryRun {
+				continue
+			}
+			err := c.connectGNS3Port(report.LabPath, report.NodeIDs[end.node.Name], end.node, end.port, nodeMappings[end.node.NodeID].InterfaceOffset, networkID)
+			if err != nil {
+				report.SkippedLinks = append(report.SkippedLinks, description+" ("+err.Error()+")")
+				break
+			}
+		}
+	}
+
+	if len(project.Topology.Drawings) > 0 {
+		report.Warnings = append(report.Warnings, strconv.Itoa(len(project.Topology.Drawings))+" drawings were not imported")
+	}
+	return report, nil
+}
+
+//---------- helper functions ----------//
+
+/*
+gns3LinkEnd - One end of a gns3 link together with what it was imported as
+*/
+type gns3LinkEnd struct {
+	node      GNS3Node
+	port      GNS3Port
+	networkID int
+	isNetwork bool
+	isNode    bool
+}
+
+func (e gns3LinkEnd) String() string {
+	return e.node.Name + ":" + e.port.Name
+}
+
+/*
+connectGNS3Port - Connects a node interface given as gns3 port to a network. The interface is looked up by the
+port name first and by the position of the port on the node otherwise, see gns3PortIndex.
+*/
+func (c *EveNgClient) connectGNS3Port(labPath LabPath, nodeID int, node GNS3Node, port GNS3Port, interfaceOffset int, networkID int) error {
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	for _, name := range []string{port.Name, port.ShortName} {
+		if name == "" {
+			continue
+		}
+		iface, findErr := interfaces.FindInterfaceOfType(name, InterfaceTypeEthernet)
+		if findErr == nil {
+			return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, iface.ID, networkID)
+		}
+		err = findErr
+	}
+	index, indexErr := gns3PortIndex(node, port)
+	if indexErr != nil {
+		return errors.Wrap(err, indexErr.Error())
+	}
+	index += interfaceOffset
+	if index < 0 || index >= len(interfaces.Ethernet) {
+		return errors.Wrap(err, "adapter "+strconv.Itoa(port.AdapterNumber)+" port "+strconv.Itoa(port.PortNumber)+" out of range")
+	}
+	return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, interfaces.Ethernet[index].ID, networkID)
+}
+
+/*
+connectGNS3SerialPort - Connects a serial node interface given as gns3 port to a network
+*/
+func (c *EveNgClient) connectGNS3SerialPort(labPath LabPath, nodeID int, port GNS3Port, networkID int) error {
+	interfaces, err := c.GetNodeInterfaces(labPath, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	var iface Interface
+	for _, name := range []string{port.Name, port.ShortName} {
+		if name == "" {
+			continue
+		}
+		iface, err = interfaces.FindInterfaceOfType(name, InterfaceTypeSerial)
+		if err == nil {
+			return c.ConnectNodeInterfaceToNetwork(labPath, nodeID, iface.ID, networkID)
+		}
+	}
+	return errors.Wrap(err, "node "+strconv.Itoa(nodeID))
+}
+
+/*
+gns3PortIndex - Returns the position of an ethernet port among the ethernet ports of its node ordered by adapter and
+port number, so every port of an adapter gets its own index. Without a port list the adapter number is used, which is
+only unambiguous for the first port of an adapter.
+*/
+func gns3PortIndex(node GNS3Node, port GNS3Port) (int, error) {
+	index, found := 0, false
+	for _, p := range node.Ports {
+		if p.LinkType == "serial" {
+			continue
+		}
+		if p.AdapterNumber == port.AdapterNumber && p.PortNumber == port.PortNumber {
+			found = true
+			continue
+		}
+		if p.AdapterNumber < port.AdapterNumber || p.AdapterNumber == port.AdapterNumber && p.PortNumber < port.PortNumber {
+			index++
+		}
+	}
+	switch {
+	case found:
+		return index, nil
+	case port.PortNumber == 0:
+		return port.AdapterNumber, nil
+	}
+	return 0, errors.New("port " + strconv.Itoa(port.PortNumber) + " of adapter " + strconv.Itoa(port.AdapterNumber) + " is not listed in the ports of node " + node.Name)
+}
+
+/*
+port - Returns the port of the node a link end is attached to
+*/
+func (n GNS3Node) port(linkNode GNS3LinkNode) GNS3Port {
+	for _, port := range n.Ports {
+		if port.AdapterNumber == linkNode.AdapterNumber && port.PortNumber == linkNode.PortNumber {
+			return port
+		}
+	}
+	return GNS3Port{
+		Name:          "adapter" + strconv.Itoa(linkNode.AdapterNumber) + "/" + strconv.Itoa(linkNode.PortNumber),
+		AdapterNumber: linkNode.AdapterNumber,
+		PortNumber:    linkNode.PortNumber,
+	}
+}
+
+/*
+image - Returns the image name of a node, gns3 stores it in different properties depending on the node type
+*/
+func (n GNS3Node) image() string {
+	for _, property := range []string{"image", "hda_disk_image", "path"} {
+		if image, ok := n.Properties[property].(string); ok && image != "" {
+			return filepath.Base(image)
+		}
+	}
+	return ""
+}
@@ -5,6 +5,8 @@ go 1.13
 require (
 	github.com/go-resty/resty/v2 v2.1.0
 	github.com/pkg/errors v0.9.1
-	github.com/spf13/viper v1.6.2
+	github.com/spf13/cobra v1.0.0
+	github.com/spf13/pflag v1.0.3
 	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.2.4
 )
@@ -0,0 +1,240 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// eve-ng marks templates without installed images by appending this suffix to their description
+const templateMissingSuffix = ".missing"
+
+/*
+ImageList contains the sorted names of the images installed for a template
+*/
+type ImageList []string
+
+/*
+AvailableImages maps template names to their installed images. Templates without installed images have an empty list.
+*/
+type AvailableImages map[string]ImageList
+
+/*
+NoImagesInstalledError - Is returned when a template has no images installed
+*/
+type NoImagesInstalledError struct {
+	Template string
+}
+
+func (e *NoImagesInstalledError) Error() string {
+	return "template " + e.Template + " has no images installed"
+}
+
+/*
+UnmarshalJSON - Decodes an image list which eve-ng returns as object keyed by image name, or as empty array if no
+images are installed
+*/
+func (l *ImageList) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var images map[string]interface{}
+	if err := json.Unmarshal(data, &images); err != nil {
+		var list []string
+		if json.Unmarshal(data, &list) != nil {
+			return errors.Wrap(err, "failed to unmarshal image list")
+		}
+		sort.Strings(list)
+		*l = list
+		return nil
+	}
+	list := make(ImageList, 0, len(images))
+	for name := range images {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	*l = list
+	return nil
+}
+
+/*
+Contains checks whether the image is installed
+*/
+func (l ImageList) Contains(image string) bool {
+	for _, name := range l {
+		if name == image {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Newest returns the image with the highest version. Numbers in image names are compared numerically, so
+"veos-4.20.1F" is newer than "veos-4.9.3F".
+*/
+func (l ImageList) Newest() (string, bool) {
+	if len(l) == 0 {
+		return "", false
+	}
+	newest := l[0]
+	for _, image := range l[1:] {
+		if compareVersions(image, newest) > 0 {
+			newest = image
+		}
+	}
+	return newest, true
+}
+
+/*
+Newest returns the newest installed image of a template, see ImageList.Newest. A NoImagesInstalledError is returned
+if the template has no images installed.
+*/
+func (a AvailableImages) Newest(template string) (string, error) {
+	image, ok := a[template].Newest()
+	if !ok {
+		return "", &NoImagesInstalledError{Template: template}
+	}
+	return image, nil
+}
+
+/*
+WithImages returns the names of all templates which have images installed
+*/
+func (a AvailableImages) WithImages() []string {
+	var templates []string
+	for template, images := range a {
+		if len(images) > 0 {
+			templates = append(templates, template)
+		}
+	}
+	sort.Strings(templates)
+	return templates
+}
+
+/*
+GetAvailableImages returns the installed images of all templates. Templates which eve-ng marks as missing are not
+retrieved, the others are retrieved concurrently.
+*/
+func (c *EveNgClient) GetAvailableImages() (AvailableImages, error) {
+	if !c.isValid() {
+		return nil, &NotValidError{}
+	}
+	templates, err := c.GetNodeTemplates()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving templates")
+	}
+	images := make(AvailableImages, len(templates))
+	var names []string
+	for name, description := range templates {
+		if strings.HasSuffix(description, templateMissingSuffix) {
+			images[name] = ImageList{}
+			continue
+		}
+		names = append(names, name)
+	}
+	details, err := c.getNodeTemplates(names)
+	if err != nil {
+		return nil, err
+	}
+	for name, template := range details {
+		images[name] = template.Options.Image.List
+		if images[name] == nil {
+			images[name] = ImageList{}
+		}
+	}
+	return images, nil
+}
+
+//---------- helper functions ----------//
+
+/*
+getNodeTemplates - Retrieves the given templates concurrently
+*/
+func (c *EveNgClient) getNodeTemplates(names []string) (map[string]Template, error) {
+	var (
+		wait     sync.WaitGroup
+		mutex    sync.Mutex
+		firstErr error
+	)
+	templates := make(map[string]Template, len(names))
+	semaphore := make(chan struct{}, 4)
+	for _, name := range names {
+		semaphore <- struct{}{}
+		mutex.Lock()
+		failed := firstErr != nil
+		mutex.Unlock()
+		if failed {
+			<-semaphore
+			break
+		}
+		wait.Add(1)
+		go func(name string) {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+			template, err := c.GetNodeTemplate(name)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrap(err, "error while retrieving template "+name)
+				}
+				return
+			}
+			templates[name] = template
+		}(name)
+	}
+	wait.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return templates, nil
+}
+
+/*
+compareVersions - Compares two strings, runs of digits are compared by their numeric value
+*/
+func compareVersions(a, b string) int {
+	partsA, partsB := versionParts(a), versionParts(b)
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if partsA[i] == partsB[i] {
+			continue
+		}
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		if errA == nil && errB == nil && numberA != numberB {
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		}
+		if partsA[i] < partsB[i] {
+			return -1
+		}
+		return 1
+	}
+	return len(partsA) - len(partsB)
+}
+
+/*
+versionParts - Splits a string into runs of digits and other characters
+*/
+func versionParts(s string) []string {
+	var parts []string
+	start := 0
+	for i, r := range s {
+		if i > 0 && unicode.IsDigit(r) != unicode.IsDigit(rune(s[i-1])) {
+			parts = append(parts, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
@@ -0,0 +1,200 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+/*
+InterfaceType distinguishes ethernet from serial node interfaces
+*/
+type InterfaceType string
+
+const (
+	// InterfaceTypeEthernet marks an ethernet interface which can be connected to a network
+	InterfaceTypeEthernet InterfaceType = "ethernet"
+	// InterfaceTypeSerial marks a serial interface which can be connected to another nodes serial interface
+	InterfaceTypeSerial InterfaceType = "serial"
+)
+
+/*
+InterfaceNotFoundError - Is returned when no interface of a node matches the given name
+*/
+type InterfaceNotFoundError struct {
+	Name       string
+	Type       InterfaceType
+	Candidates []string
+	ValidNames []string
+}
+
+func (e *InterfaceNotFoundError) Error() string {
+	kind := "interface"
+	if e.Type != "" {
+		kind = string(e.Type) + " interface"
+	}
+	if len(e.Candidates) > 0 {
+		return kind + " name '" + e.Name + "' is ambiguous, it matches: " + strings.Join(e.Candidates, ", ")
+	}
+	if len(e.ValidNames) == 0 {
+		return "no " + kind + " named '" + e.Name + "' found, node has no " + kind + "s"
+	}
+	return "no " + kind + " named '" + e.Name + "' found, valid names are: " + strings.Join(e.ValidNames, ", ")
+}
+
+/*
+UnmarshalJSON - Decodes ethernet interfaces which eve-ng returns either as array (qemu) or as object keyed by interface id (iol, dynamips)
+*/
+func (e *EthernetInterfaces) UnmarshalJSON(data []byte) error {
+	interfaces, err := unmarshalInterfaces(data)
+	if err != nil {
+		return err
+	}
+	*e = interfaces
+	return nil
+}
+
+/*
+UnmarshalJSON - Decodes serial interfaces which eve-ng returns either as array or as object keyed by interface id
+*/
+func (s *SerialInterfaces) UnmarshalJSON(data []byte) error {
+	interfaces, err := unmarshalInterfaces(data)
+	if err != nil {
+		return err
+	}
+	*s = interfaces
+	return nil
+}
+
+/*
+FindInterface - Looks up an ethernet or serial interface by its name, e.g. "e0/1", "Gi0/0/0/1" or "Ethernet3"
+*/
+func (i Interfaces) FindInterface(name string) (Interface, InterfaceType, error) {
+	var names []string
+	var matches []Interface
+	var matchTypes []InterfaceType
+	for _, interfaceType := range []InterfaceType{InterfaceTypeEthernet, InterfaceTypeSerial} {
+		iface, err := i.FindInterfaceOfType(name, interfaceType)
+		if err == nil {
+			matches = append(matches, iface)
+			matchTypes = append(matchTypes, interfaceType)
+			continue
+		}
+		notFound, ok := err.(*InterfaceNotFoundError)
+		if !ok {
+			return Interface{}, "", err
+		}
+		if len(notFound.Candidates) > 0 {
+			return Interface{}, "", &InterfaceNotFoundError{Name: name, Candidates: notFound.Candidates}
+		}
+		names = append(names, notFound.ValidNames...)
+	}
+	switch len(matches) {
+	case 0:
+		return Interface{}, "", &InterfaceNotFoundError{Name: name, ValidNames: names}
+	case 1:
+		return matches[0], matchTypes[0], nil
+	}
+	return Interface{}, "", &InterfaceNotFoundError{Name: name, Candidates: []string{matches[0].Name, matches[1].Name}}
+}
+
+/*
+FindInterfaceOfType - Looks up an interface of the given type by its name.
+
+An exact (case insensitive) match is preferred. Otherwise abbreviations are resolved the way network operating
+systems do it, so "Ethernet3" matches "Eth3" and "gi0/0" matches "GigabitEthernet0/0".
+*/
+func (i Interfaces) FindInterfaceOfType(name string, interfaceType InterfaceType) (Interface, error) {
+	var interfaces []Interface
+	switch interfaceType {
+	case InterfaceTypeEthernet:
+		interfaces = i.Ethernet
+	case InterfaceTypeSerial:
+		interfaces = i.Serial
+	default:
+		return Interface{}, errors.New("invalid interface type: " + string(interfaceType))
+	}
+
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if strings.EqualFold(iface.Name, name) {
+			return iface, nil
+		}
+		names = append(names, iface.Name)
+	}
+
+	wantPrefix, wantSuffix := splitInterfaceName(name)
+	var matches []Interface
+	for _, iface := range interfaces {
+		prefix, suffix := splitInterfaceName(iface.Name)
+		if suffix != wantSuffix || prefix == "" || wantPrefix == "" {
+			continue
+		}
+		if strings.HasPrefix(prefix, wantPrefix) || strings.HasPrefix(wantPrefix, prefix) {
+			matches = append(matches, iface)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		sort.Strings(names)
+		return Interface{}, &InterfaceNotFoundError{Name: name, Type: interfaceType, ValidNames: names}
+	case 1:
+		return matches[0], nil
+	}
+	candidates := make([]string, 0, len(matches))
+	for _, iface := range matches {
+		candidates = append(candidates, iface.Name)
+	}
+	return Interface{}, &InterfaceNotFoundError{Name: name, Type: interfaceType, Candidates: candidates}
+}
+
+//---------- helper functions ----------//
+
+/*
+unmarshalInterfaces - Decodes a list of interfaces and sets their ids according to their position or key in the response
+*/
+func unmarshalInterfaces(data []byte) ([]Interface, error) {
+	var list []Interface
+	if err := json.Unmarshal(data, &list); err == nil {
+		for id := range list {
+			list[id].ID = id
+		}
+		return list, nil
+	}
+
+	var byID map[string]Interface
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return nil, errors.Wrap(err, "interfaces are neither a list nor an object")
+	}
+	list = make([]Interface, 0, len(byID))
+	for key, iface := range byID {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid interface id '"+key+"'")
+		}
+		iface.ID = id
+		list = append(list, iface)
+	}
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].ID < list[b].ID
+	})
+	return list, nil
+}
+
+/*
+splitInterfaceName - Splits an interface name into its lower case alphabetic prefix and the remaining port numbering
+*/
+func splitInterfaceName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	index := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '_'
+	})
+	if index == -1 {
+		return strings.ToLower(name), ""
+	}
+	return strings.ToLower(name[:index]), strings.TrimSpace(name[index:])
+}
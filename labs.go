@@ -0,0 +1,200 @@
+package evengclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*
+LabSpec contains the metadata a lab should have. Empty fields are not compared with an existing lab and left to
+the eve-ng defaults when the lab is created. eve-ng names the lab file after the lab, so the name has to be the lab
+file name without the .unl suffix, an empty name defaults to it.
+Nodes are used to plan the resources a lab needs, EnsureLab does not create them.
+*/
+type LabSpec struct {
+	Name        string
+	Version     string
+	Author      string
+	Description string
+	Body        string
+	Nodes       []NodeSpec
+}
+
+/*
+NodeSpec describes a node of a lab. RAM is given in MB, Count is the number of identical nodes and defaults to 1.
+*/
+type NodeSpec struct {
+	Name     string
+	Type     string
+	Template string
+	Image    string
+	Icon     string
+	Console  string
+	RAM      int
+	CPU      int
+	Ethernet int
+	Delay    int
+	Left     int
+	Top      int
+	Count    int
+}
+
+/*
+LabNotFoundError - Is returned when no lab matches the given path or id
+*/
+type LabNotFoundError struct {
+	Path LabPath
+	ID   string
+}
+
+func (e *LabNotFoundError) Error() string {
+	if e.ID != "" {
+		return "no lab with id '" + e.ID + "' found"
+	}
+	return "lab '" + e.Path.String() + "' does not exist"
+}
+
+/*
+LabExists checks whether a lab exists by listing the folder containing it, the lab itself is not loaded
+*/
+func (c *EveNgClient) LabExists(labPath LabPath) (bool, error) {
+	if !c.isValid() {
+		return false, &NotValidError{}
+	}
+	labFiles, err := c.GetLabFiles(labPath.Folder())
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "error while retrieving lab files")
+	}
+	for _, labFile := range labFiles {
+		if labFile.File == labPath.File() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+/*
+FindLabByID searches all folders for the lab with the given id and returns its path together with the lab. A
+LabNotFoundError is returned if no lab has the id.
+*/
+func (c *EveNgClient) FindLabByID(id string) (LabPath, Lab, error) {
+	labs, err := c.FindLabs(RootFolder, "*")
+	if err != nil {
+		return "", Lab{}, errors.Wrap(err, "error while searching labs")
+	}
+
+	var (
+		wait      sync.WaitGroup
+		mutex     sync.Mutex
+		foundPath LabPath
+		foundLab  Lab
+		firstErr  error
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	semaphore := make(chan struct{}, 4)
+	for _, labFile := range labs {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wait.Add(1)
+		go func(labPath LabPath) {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+			lab, err := c.GetLab(labPath)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				// labs can be removed while searching
+				if firstErr == nil && !isNotFoundError(err) {
+					firstErr = errors.Wrap(err, "error while retrieving lab "+labPath.String())
+				}
+				return
+			}
+			if lab.ID == id && foundPath == "" {
+				foundPath, foundLab = labPath, lab
+				cancel()
+			}
+		}(labFile.LabPath())
+	}
+	wait.Wait()
+
+	if foundPath != "" {
+		return foundPath, foundLab, nil
+	}
+	if firstErr != nil {
+		return "", Lab{}, firstErr
+	}
+	return "", Lab{}, &LabNotFoundError{ID: id}
+}
+
+/*
+EnsureLab creates the lab (and its folder) if it does not exist, or updates its metadata if it differs from the
+spec. It returns whether anything was changed. The body of an existing lab can not be edited by the api and is not
+compared. A spec name other than the lab file name is rejected, creating or renaming the lab would move it away from
+labPath.
+*/
+func (c *EveNgClient) EnsureLab(labPath LabPath, spec LabSpec) (bool, error) {
+	if !c.isValid() {
+		return false, &NotValidError{}
+	}
+	if spec.Name == "" {
+		spec.Name = labPath.Name()
+	}
+	if spec.Name != labPath.Name() {
+		return false, errors.New("lab name '" + spec.Name + "' does not match lab file " + labPath.String())
+	}
+
+	exists, err := c.LabExists(labPath)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		folder := labPath.Folder()
+		err = c.EnsureFolder(folder)
+		if err != nil {
+			return false, errors.Wrap(err, "error while creating folder "+folder.String())
+		}
+		version := spec.Version
+		if version == "" {
+			version = "1"
+		}
+		err = c.AddLab(folder, spec.Name, version, spec.Author, spec.Description, spec.Body)
+		if err != nil {
+			return false, errors.Wrap(err, "error while creating lab")
+		}
+		return true, nil
+	}
+
+	lab, err := c.GetLab(labPath)
+	if err != nil {
+		return false, errors.Wrap(err, "error while retrieving lab")
+	}
+	wanted := lab
+	if spec.Version != "" {
+		wanted.Version = spec.Version
+	}
+	if spec.Author != "" {
+		wanted.Author = spec.Author
+	}
+	if spec.Description != "" {
+		wanted.Description = spec.Description
+	}
+	if wanted == lab {
+		return false, nil
+	}
+	err = c.EditLab(labPath, wanted.Name, wanted.Version, wanted.Author, wanted.Description)
+	if err != nil {
+		return false, errors.Wrap(err, "error while editing lab")
+	}
+	return true, nil
+}
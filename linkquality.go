@@ -0,0 +1,75 @@
+package evengclient
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Impairment contains the link quality settings of a node interface. Delay and jitter are given in milliseconds, loss
+in percent and bandwidth in kbit/s where 0 means unlimited.
+*/
+type Impairment struct {
+	Delay     int `json:"delay"`
+	Jitter    int `json:"jitter"`
+	Loss      int `json:"loss"`
+	Bandwidth int `json:"bandwidth"`
+}
+
+/*
+Validate checks that all values of the impairment are within their valid range
+*/
+func (i Impairment) Validate() error {
+	if i.Delay < 0 {
+		return errors.New("invalid delay " + strconv.Itoa(i.Delay) + ": must not be negative")
+	}
+	if i.Jitter < 0 {
+		return errors.New("invalid jitter " + strconv.Itoa(i.Jitter) + ": must not be negative")
+	}
+	if i.Loss < 0 || i.Loss > 100 {
+		return errors.New("invalid loss " + strconv.Itoa(i.Loss) + ": must be between 0 and 100")
+	}
+	if i.Bandwidth < 0 {
+		return errors.New("invalid bandwidth " + strconv.Itoa(i.Bandwidth) + ": must not be negative")
+	}
+	return nil
+}
+
+/*
+SourceImpairment returns the link quality settings of the source interface of a topology point
+*/
+func (t Topology) SourceImpairment() Impairment {
+	return Impairment{Delay: t.SourceDelay, Jitter: t.SourceJitter, Loss: t.SourceLoss, Bandwidth: t.SourceBandwidth}
+}
+
+/*
+DestinationImpairment returns the link quality settings of the destination interface of a topology point
+*/
+func (t Topology) DestinationImpairment() Impairment {
+	return Impairment{Delay: t.DestinationDelay, Jitter: t.DestinationJitter, Loss: t.DestinationLoss, Bandwidth: t.DestinationBandwidth}
+}
+
+/*
+LinkState contains the link quality settings and the suspend state of a node interface
+*/
+type LinkState struct {
+	Impairment
+	Suspended bool
+}
+
+/*
+findLinkState - Looks up the link state of a node interface in a lab topology
+*/
+func findLinkState(topology TopologyPoints, nodeID int, interfaceID int) (LinkState, error) {
+	node := Vertex{Kind: VertexKindNode, ID: nodeID}.String()
+	for _, point := range topology {
+		if point.Source == node && point.SourceInterfaceID == interfaceID {
+			return LinkState{Impairment: point.SourceImpairment(), Suspended: point.SourceSuspend != 0}, nil
+		}
+		if point.Destination == node && point.DestinationInterfaceID == strconv.Itoa(interfaceID) {
+			return LinkState{Impairment: point.DestinationImpairment(), Suspended: point.DestinationSuspend != 0}, nil
+		}
+	}
+	return LinkState{}, errors.New("interface " + strconv.Itoa(interfaceID) + " of node " + strconv.Itoa(nodeID) + " is not connected")
+}
@@ -0,0 +1,250 @@
+package evengclient
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// bodies passed to loggers and hooks are truncated to this length
+const maxLoggedBodyLength = 4096
+
+// replaces the values of sensitive fields in logged bodies
+const redactedValue = "[REDACTED]"
+
+/*
+Logger is a structured logger. Arguments are alternating keys and values, so a *slog.Logger can be used directly.
+*/
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+/*
+RequestInfo describes a request which is about to be sent. Body is the redacted and truncated request body.
+*/
+type RequestInfo struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+/*
+ResponseInfo describes the outcome of a request. StatusCode is 0 if no response was received, Err is the error
+returned to the caller, if any. Retries is the number of times resty retried the request. Bodies are redacted and
+truncated.
+*/
+type ResponseInfo struct {
+	Method       string
+	Path         string
+	StatusCode   int
+	Latency      time.Duration
+	Retries      int
+	RequestBody  string
+	ResponseBody string
+	Err          error
+}
+
+/*
+Hooks are called for every request sent to the server, requests served from the response cache are not reported.
+They are called synchronously and must be safe for concurrent use.
+*/
+type Hooks struct {
+	OnRequest  func(info RequestInfo)
+	OnResponse func(info ResponseInfo)
+}
+
+/*
+SetLogger logs every request: successful requests at debug level including their bodies, failed requests at warn
+level and transport errors at error level. Passwords, secrets and tokens in bodies are redacted. A nil logger disables
+logging. The logger is shared with all clients derived from this client.
+*/
+func (c *client) SetLogger(logger Logger) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	c.observer.mutex.Lock()
+	defer c.observer.mutex.Unlock()
+	c.observer.logger = logger
+	return nil
+}
+
+/*
+SetHooks sets the functions called before and after every request, unset hooks are not called. The hooks are shared
+with all clients derived from this client.
+*/
+func (c *client) SetHooks(hooks Hooks) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	c.observer.mutex.Lock()
+	defer c.observer.mutex.Unlock()
+	c.observer.hooks = hooks
+	return nil
+}
+
+//---------- helper functions ----------//
+
+/*
+requestObserver - The logger and hooks of a client
+*/
+type requestObserver struct {
+	mutex  sync.RWMutex
+	logger Logger
+	hooks  Hooks
+}
+
+/*
+requestTrace - Collects the details of a single request for the logger and hooks
+*/
+type requestTrace struct {
+	logger   Logger
+	hooks    Hooks
+	method   string
+	path     string
+	body     string
+	start    time.Time
+	attempts int32
+}
+
+// context key of the attempt counter of a request
+type requestAttemptsKey struct{}
+
+/*
+start - Reports a request which is about to be sent, the returned trace is nil if nothing observes requests
+*/
+func (o *requestObserver) start(request *resty.Request, method, path string) *requestTrace {
+	if o == nil {
+		return nil
+	}
+	o.mutex.RLock()
+	logger, hooks := o.logger, o.hooks
+	o.mutex.RUnlock()
+	if logger == nil && hooks.OnRequest == nil && hooks.OnResponse == nil {
+		return nil
+	}
+
+	trace := &requestTrace{logger: logger, hooks: hooks, method: method, path: path}
+	if body, ok := request.Body.(string); ok {
+		trace.body = redactBody([]byte(body))
+	}
+	request.SetContext(context.WithValue(request.Context(), requestAttemptsKey{}, &trace.attempts))
+	if hooks.OnRequest != nil {
+		hooks.OnRequest(RequestInfo{Method: method, Path: path, Body: trace.body})
+	}
+	trace.start = time.Now()
+	return trace
+}
+
+/*
+finish - Reports the outcome of a traced request
+*/
+func (t *requestTrace) finish(response *resty.Response, err error) {
+	if t == nil {
+		return
+	}
+	info := ResponseInfo{
+		Method:      t.method,
+		Path:        t.path,
+		Latency:     time.Since(t.start),
+		RequestBody: t.body,
+		Err:         err,
+	}
+	if attempts := atomic.LoadInt32(&t.attempts); attempts > 1 {
+		info.Retries = int(attempts) - 1
+	}
+	if response != nil && response.RawResponse != nil {
+		info.StatusCode = response.StatusCode()
+		info.ResponseBody = redactBody(response.Body())
+	}
+	if t.hooks.OnResponse != nil {
+		t.hooks.OnResponse(info)
+	}
+	if t.logger == nil {
+		return
+	}
+	args := []interface{}{"method", info.Method, "path", info.Path, "status", info.StatusCode, "latency", info.Latency, "retries", info.Retries}
+	switch {
+	case info.StatusCode == 0 && err != nil:
+		t.logger.Error("eve-ng request failed", append(args, "error", err.Error())...)
+	case err != nil:
+		t.logger.Warn("eve-ng request failed", append(args, "error", err.Error(), "response_body", info.ResponseBody)...)
+	default:
+		t.logger.Debug("eve-ng request", append(args, "request_body", info.RequestBody, "response_body", info.ResponseBody)...)
+	}
+}
+
+/*
+countAttempt - Resty middleware counting the attempts of a traced request, which is called again for every retry
+*/
+func countAttempt(_ *resty.Client, request *resty.Request) error {
+	if attempts, ok := request.Context().Value(requestAttemptsKey{}).(*int32); ok {
+		atomic.AddInt32(attempts, 1)
+	}
+	return nil
+}
+
+/*
+redactBody - Replaces the values of sensitive json fields and truncates the body, binary bodies are replaced by their
+length
+*/
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var data interface{}
+	if json.Unmarshal(body, &data) == nil {
+		if redacted, err := json.Marshal(redactValue(data)); err == nil {
+			body = redacted
+		}
+	} else if !utf8.Valid(body) {
+		return "[" + strconv.Itoa(len(body)) + " bytes]"
+	}
+	if len(body) > maxLoggedBodyLength {
+		return string(body[:maxLoggedBodyLength]) + "...[truncated]"
+	}
+	return string(body)
+}
+
+/*
+redactValue - Recursively replaces the values of sensitive keys in decoded json
+*/
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+	return value
+}
+
+/*
+isSensitiveKey - Checks whether a json key holds credentials, e.g. "password" or "rdp_password"
+*/
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"password", "secret", "token", "cookie"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
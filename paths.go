@@ -0,0 +1,199 @@
+package evengclient
+
+import (
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+/*
+LabPath is the path of a lab file. Lab paths are normalized when they are used, so "/Folder/Lab.unl",
+"Folder/Lab.unl" and "Folder/Lab" all refer to the same lab.
+*/
+type LabPath string
+
+/*
+FolderPath is the path of a folder, "/" or "" is the root folder. Folder paths are normalized when they are used.
+*/
+type FolderPath string
+
+/*
+RootFolder is the root folder of the lab tree
+*/
+const RootFolder FolderPath = "/"
+
+const labFileSuffix = ".unl"
+
+/*
+ParseLabPath normalizes and validates a lab path
+*/
+func ParseLabPath(labPath string) (LabPath, error) {
+	if err := validatePathSegments(labPath); err != nil {
+		return "", errors.Wrap(err, "invalid lab path '"+labPath+"'")
+	}
+	p := LabPath(labPath)
+	if p.Name() == "" {
+		return "", errors.New("invalid lab path '" + labPath + "': lab name is empty")
+	}
+	return LabPath(p.String()), nil
+}
+
+/*
+ParseFolderPath normalizes and validates a folder path
+*/
+func ParseFolderPath(folderPath string) (FolderPath, error) {
+	if err := validatePathSegments(folderPath); err != nil {
+		return "", errors.Wrap(err, "invalid folder path '"+folderPath+"'")
+	}
+	return FolderPath(FolderPath(folderPath).String()), nil
+}
+
+/*
+String returns the normalized lab path, e.g. "/Folder/Lab.unl"
+*/
+func (p LabPath) String() string {
+	labPath := path.Clean("/" + string(p))
+	if !strings.HasSuffix(labPath, labFileSuffix) {
+		labPath += labFileSuffix
+	}
+	return labPath
+}
+
+/*
+Folder returns the folder containing the lab
+*/
+func (p LabPath) Folder() FolderPath {
+	return FolderPath(path.Dir(p.String()))
+}
+
+/*
+File returns the file name of the lab, e.g. "Lab.unl"
+*/
+func (p LabPath) File() string {
+	return path.Base(p.String())
+}
+
+/*
+Name returns the file name of the lab without the .unl suffix
+*/
+func (p LabPath) Name() string {
+	return strings.TrimSuffix(p.File(), labFileSuffix)
+}
+
+/*
+Validate checks that the lab path has a name and contains no parent folder references or control characters
+*/
+func (p LabPath) Validate() error {
+	_, err := ParseLabPath(string(p))
+	return err
+}
+
+/*
+String returns the normalized folder path, e.g. "/Folder/Subfolder" or "/" for the root folder
+*/
+func (p FolderPath) String() string {
+	return path.Clean("/" + string(p))
+}
+
+/*
+IsRoot checks whether the path is the root folder
+*/
+func (p FolderPath) IsRoot() bool {
+	return p.String() == "/"
+}
+
+/*
+Parent returns the folder containing the folder, the parent of the root folder is the root folder
+*/
+func (p FolderPath) Parent() FolderPath {
+	return FolderPath(path.Dir(p.String()))
+}
+
+/*
+Base returns the name of the folder, which is "/" for the root folder
+*/
+func (p FolderPath) Base() string {
+	return path.Base(p.String())
+}
+
+/*
+Join returns the path of a subfolder
+*/
+func (p FolderPath) Join(elem ...string) FolderPath {
+	return FolderPath(path.Join(append([]string{p.String()}, elem...)...))
+}
+
+/*
+Lab returns the path of a lab in the folder, the .unl suffix is added if name does not have it
+*/
+func (p FolderPath) Lab(name string) LabPath {
+	return LabPath(LabPath(path.Join(p.String(), name)).String())
+}
+
+/*
+Segments returns the folder names of the path from the root folder downwards
+*/
+func (p FolderPath) Segments() []string {
+	if p.IsRoot() {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(p.String(), "/"), "/")
+}
+
+/*
+Validate checks that the folder path contains no parent folder references or control characters
+*/
+func (p FolderPath) Validate() error {
+	_, err := ParseFolderPath(string(p))
+	return err
+}
+
+/*
+LabPath returns the path of the lab file
+*/
+func (l LabFile) LabPath() LabPath {
+	return LabPath(l.Path)
+}
+
+/*
+FolderPath returns the path of the folder
+*/
+func (f Folder) FolderPath() FolderPath {
+	return FolderPath(f.Path)
+}
+
+//---------- helper functions ----------//
+
+/*
+api - Returns the lab path as used in api urls, without leading slash
+*/
+func (p LabPath) api() string {
+	return strings.TrimPrefix(p.String(), "/")
+}
+
+/*
+api - Returns the folder path as used in api urls, without leading slash
+*/
+func (p FolderPath) api() string {
+	return strings.TrimPrefix(p.String(), "/")
+}
+
+/*
+validatePathSegments - Rejects parent folder references and control characters, which would let a path escape
+its folder or break the api url
+*/
+func validatePathSegments(p string) error {
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return errors.New("parent folder references are not allowed")
+		}
+		for _, r := range segment {
+			if unicode.IsControl(r) {
+				return errors.New("control characters are not allowed")
+			}
+		}
+	}
+	return nil
+}
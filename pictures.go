@@ -0,0 +1,97 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"html"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+ImageMapShape is the shape of a clickable area in a picture image map
+*/
+type ImageMapShape string
+
+const (
+	// ImageMapShapeRect is a rectangle given by the coordinates left, top, right, bottom
+	ImageMapShapeRect ImageMapShape = "rect"
+	// ImageMapShapeCircle is a circle given by the coordinates x, y, radius
+	ImageMapShapeCircle ImageMapShape = "circle"
+	// ImageMapShapePoly is a polygon given by the coordinates x1, y1, x2, y2, ...
+	ImageMapShapePoly ImageMapShape = "poly"
+)
+
+/*
+ImageMapArea is a clickable area of a picture which opens the console of a lab node
+*/
+type ImageMapArea struct {
+	Shape  ImageMapShape
+	Coords []int
+	NodeID int
+	Alt    string
+}
+
+/*
+UnmarshalJSON - Decodes pictures which eve-ng returns as object keyed by picture id
+*/
+func (p *Pictures) UnmarshalJSON(data []byte) error {
+	var list []Picture
+	if err := json.Unmarshal(data, &list); err == nil {
+		*p = list
+		return nil
+	}
+	var byID map[string]Picture
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return errors.Wrap(err, "pictures are neither a list nor an object")
+	}
+	list = make([]Picture, 0, len(byID))
+	for key, picture := range byID {
+		if picture.ID == 0 {
+			picture.ID, _ = strconv.Atoi(key)
+		}
+		list = append(list, picture)
+	}
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].ID < list[b].ID
+	})
+	*p = list
+	return nil
+}
+
+/*
+BuildImageMap returns the eve-ng image map for the given areas. Each area links to the console of its node using
+the placeholders eve-ng replaces when the picture is shown.
+*/
+func BuildImageMap(areas []ImageMapArea) string {
+	lines := make([]string, 0, len(areas))
+	for _, area := range areas {
+		coords := make([]string, 0, len(area.Coords))
+		for _, coord := range area.Coords {
+			coords = append(coords, strconv.Itoa(coord))
+		}
+		alt := area.Alt
+		if alt == "" {
+			alt = "Node " + strconv.Itoa(area.NodeID)
+		}
+		lines = append(lines, "<area shape='"+string(area.Shape)+"' alt='"+html.EscapeString(alt)+"' coords='"+strings.Join(coords, ",")+"' href='telnet://{{IP}}:{{NODE"+strconv.Itoa(area.NodeID)+"}}'>")
+	}
+	return strings.Join(lines, "\n")
+}
+
+//---------- helper functions ----------//
+
+/*
+pictureContentType - Returns the content type of a png or jpeg image and an error for any other file
+*/
+func pictureContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/png", "image/jpeg":
+		return contentType, nil
+	}
+	return "", errors.New("picture must be a png or jpeg image, got " + contentType)
+}
@@ -0,0 +1,639 @@
+package evengclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// CredentialSourceEnv reads the credentials from environment variables, see EnvCredentials
+	CredentialSourceEnv = "env"
+	// CredentialSourceFile reads the credentials from a yaml file, see FileCredentials
+	CredentialSourceFile = "file"
+	// CredentialSourceNetrc reads the credentials from a netrc file, see NetrcCredentials
+	CredentialSourceNetrc = "netrc"
+	// CredentialSourceKeyring reads the credentials from a keyring file, see KeyringFileCredentials
+	CredentialSourceKeyring = "keyring"
+	// CredentialSourceCommand reads the credentials from the output of a command, see CommandCredentials
+	CredentialSourceCommand = "command"
+)
+
+const (
+	// ProfilesFileEnv is the environment variable which overrides the path of the profiles file
+	ProfilesFileEnv = "EVE_NG_API_PROFILES"
+	// ProfileEnv is the environment variable which selects the profile if no profile name is given
+	ProfileEnv = "EVE_NG_API_PROFILE"
+
+	// LegacyConfigFile is the config file of earlier versions, relative to the working directory
+	LegacyConfigFile = "config/eve-ng-api.yaml"
+
+	envPrefix      = "EVE_NG_API"
+	commandTimeout = 30 * time.Second
+)
+
+/*
+Profiles is the content of a profiles file, which contains named eve-ng servers
+
+	default: lab1
+	profiles:
+	  lab1:
+	    url: https://eve-ng1.example.com
+	    username: admin
+	    credentials:
+	      type: command
+	      command: [pass, show, eve-ng/lab1]
+	  lab2:
+	    url: https://eve-ng2.example.com
+	    credentials:
+	      type: netrc
+*/
+type Profiles struct {
+	Default  string             `yaml:"default"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+/*
+Profile is a named eve-ng server. Username and Password are used as they are, a configured credential source fills in
+what they leave empty.
+*/
+type Profile struct {
+	Name        string            `yaml:"-"`
+	BaseURL     string            `yaml:"url"`
+	Username    string            `yaml:"username"`
+	Password    string            `yaml:"password"`
+	Credentials *CredentialSource `yaml:"credentials"`
+
+	// Provider overrides the credential source, it can be set to plug in custom providers
+	Provider CredentialProvider `yaml:"-"`
+}
+
+/*
+CredentialSource configures the credential provider of a profile. Type is one of the CredentialSource* constants,
+Prefix is used by env, Path by file, netrc and keyring and Command by command.
+*/
+type CredentialSource struct {
+	Type    string   `yaml:"type"`
+	Prefix  string   `yaml:"prefix"`
+	Path    string   `yaml:"path"`
+	Command []string `yaml:"command"`
+}
+
+/*
+Credentials are a username and password
+*/
+type Credentials struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+/*
+CredentialProvider looks up the credentials of a profile
+*/
+type CredentialProvider interface {
+	Credentials(profile Profile) (Credentials, error)
+}
+
+/*
+ProfileNotFoundError is returned if a profiles file does not contain the requested profile
+*/
+type ProfileNotFoundError struct {
+	Name      string
+	Available []string
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	return "profile '" + e.Name + "' not found, available profiles: " + strings.Join(e.Available, ", ")
+}
+
+/*
+DefaultProfilesFile returns the path of the profiles file, which is eve-ng/profiles.yaml in the user config directory
+unless EVE_NG_API_PROFILES is set
+*/
+func DefaultProfilesFile() string {
+	if filename := os.Getenv(ProfilesFileEnv); filename != "" {
+		return filename
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join("eve-ng", "profiles.yaml")
+	}
+	return filepath.Join(dir, "eve-ng", "profiles.yaml")
+}
+
+/*
+LoadProfiles reads a profiles file. An empty filename loads the default profiles file, which may be missing.
+*/
+func LoadProfiles(filename string) (*Profiles, error) {
+	profiles := &Profiles{}
+	explicit := filename != ""
+	if !explicit {
+		filename = DefaultProfilesFile()
+	}
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return profiles, nil
+		}
+		return nil, errors.Wrap(err, "error while reading profiles file")
+	}
+	err = yaml.UnmarshalStrict(b, profiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while parsing profiles file "+filename)
+	}
+	for name, profile := range profiles.Profiles {
+		if profile.Credentials != nil {
+			if _, err := profile.Credentials.Provider(); err != nil {
+				return nil, errors.Wrap(err, "invalid credentials of profile '"+name+"'")
+			}
+		}
+	}
+	return profiles, nil
+}
+
+/*
+Profile returns the profile with the given name, or the default profile if name is empty
+*/
+func (p *Profiles) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = p.Default
+	}
+	profile, ok := p.Profiles[name]
+	if !ok {
+		return Profile{}, &ProfileNotFoundError{Name: name, Available: p.Names()}
+	}
+	profile.Name = name
+	return profile, nil
+}
+
+/*
+Names returns the sorted names of all profiles
+*/
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+LoadProfile loads a profile from the default profiles file. If name is empty, the profile named by EVE_NG_API_PROFILE
+or the default profile of the file is used. Without either, the environment profile is used, see EnvironmentProfile.
+*/
+func LoadProfile(name string) (Profile, error) {
+	profiles, err := LoadProfiles("")
+	if err != nil {
+		return Profile{}, err
+	}
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+	if name == "" && profiles.Default == "" {
+		return EnvironmentProfile(), nil
+	}
+	return profiles.Profile(name)
+}
+
+/*
+EnvironmentProfile returns a profile read from the EVE_NG_API_BASEURL, EVE_NG_API_USERNAME and EVE_NG_API_PASSWORD
+environment variables. What they leave empty is read from the legacy config file, see LegacyConfigCredentials.
+*/
+func EnvironmentProfile() Profile {
+	legacy := LegacyConfigCredentials{}
+	profile := Profile{
+		Name:     "environment",
+		BaseURL:  os.Getenv(envPrefix + "_BASEURL"),
+		Username: os.Getenv(envPrefix + "_USERNAME"),
+		Password: os.Getenv(envPrefix + "_PASSWORD"),
+		Provider: legacy,
+	}
+	if profile.BaseURL == "" {
+		// an unreadable file is reported when the credentials are resolved
+		config, _ := legacy.read()
+		profile.BaseURL = config.BaseURL
+	}
+	return profile
+}
+
+/*
+ResolveCredentials returns the username and password of the profile. The credential provider is only asked if the
+profile does not contain both.
+*/
+func (p Profile) ResolveCredentials() (Credentials, error) {
+	credentials := Credentials{Username: p.Username, Password: p.Password}
+	if credentials.Username != "" && credentials.Password != "" {
+		return credentials, nil
+	}
+	provider := p.Provider
+	if provider == nil && p.Credentials != nil {
+		var err error
+		provider, err = p.Credentials.Provider()
+		if err != nil {
+			return credentials, err
+		}
+	}
+	if provider == nil {
+		return credentials, nil
+	}
+	provided, err := provider.Credentials(p)
+	if err != nil {
+		return credentials, errors.Wrap(err, "error while looking up credentials of profile '"+p.Name+"'")
+	}
+	if credentials.Username == "" {
+		credentials.Username = provided.Username
+	}
+	if credentials.Password == "" {
+		credentials.Password = provided.Password
+	}
+	return credentials, nil
+}
+
+/*
+NewEveNgClientFromProfile creates a new eve-ng api-client for the server of a profile and sets its credentials
+*/
+func NewEveNgClientFromProfile(profile Profile) (*EveNgClient, error) {
+	credentials, err := profile.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewEveNgClient(profile.BaseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating client for profile '"+profile.Name+"'")
+	}
+	err = client.SetUsernameAndPassword(credentials.Username, credentials.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while setting credentials of profile '"+profile.Name+"'")
+	}
+	return client, nil
+}
+
+/*
+Provider returns the credential provider configured by the source
+*/
+func (s CredentialSource) Provider() (CredentialProvider, error) {
+	switch s.Type {
+	case CredentialSourceEnv:
+		return EnvCredentials{Prefix: s.Prefix}, nil
+	case CredentialSourceFile:
+		if s.Path == "" {
+			return nil, errors.New("credentials of type file need a path")
+		}
+		return FileCredentials{Path: s.Path}, nil
+	case CredentialSourceNetrc:
+		return NetrcCredentials{Path: s.Path}, nil
+	case CredentialSourceKeyring:
+		if s.Path == "" {
+			return nil, errors.New("credentials of type keyring need a path")
+		}
+		return KeyringFileCredentials{Path: s.Path}, nil
+	case CredentialSourceCommand:
+		if len(s.Command) == 0 {
+			return nil, errors.New("credentials of type command need a command")
+		}
+		return CommandCredentials{Command: s.Command}, nil
+	}
+	return nil, errors.New("unknown credential source type '" + s.Type + "'")
+}
+
+/*
+EnvCredentials reads the credentials from the environment variables <Prefix>_<PROFILE>_USERNAME and
+<Prefix>_<PROFILE>_PASSWORD, falling back to <Prefix>_USERNAME and <Prefix>_PASSWORD. The profile name is upper
+cased and other characters than letters and digits are replaced by underscores. Prefix defaults to EVE_NG_API.
+*/
+type EnvCredentials struct {
+	Prefix string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (e EnvCredentials) Credentials(profile Profile) (Credentials, error) {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = envPrefix
+	}
+	lookup := func(key string) string {
+		if profile.Name != "" {
+			if value := os.Getenv(prefix + "_" + envName(profile.Name) + "_" + key); value != "" {
+				return value
+			}
+		}
+		return os.Getenv(prefix + "_" + key)
+	}
+	return Credentials{Username: lookup("USERNAME"), Password: lookup("PASSWORD")}, nil
+}
+
+/*
+FileCredentials reads the credentials from a yaml file with the keys username and password
+*/
+type FileCredentials struct {
+	Path string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (f FileCredentials) Credentials(profile Profile) (Credentials, error) {
+	var credentials Credentials
+	b, err := ioutil.ReadFile(expandHome(f.Path))
+	if err != nil {
+		return credentials, errors.Wrap(err, "error while reading credentials file")
+	}
+	err = yaml.UnmarshalStrict(b, &credentials)
+	if err != nil {
+		return credentials, errors.Wrap(err, "error while parsing credentials file")
+	}
+	return credentials, nil
+}
+
+/*
+LegacyConfigCredentials reads the credentials from the config file of earlier versions, a yaml file with the keys
+BaseUrl, Username and Password in any case. Path defaults to LegacyConfigFile. A missing file provides no credentials.
+*/
+type LegacyConfigCredentials struct {
+	Path string
+}
+
+/*
+legacyConfig - The content of a legacy config file
+*/
+type legacyConfig struct {
+	BaseURL  string
+	Username string
+	Password string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (l LegacyConfigCredentials) Credentials(profile Profile) (Credentials, error) {
+	config, err := l.read()
+	if err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{Username: config.Username, Password: config.Password}, nil
+}
+
+/*
+read - Reads the legacy config file, keys are matched case insensitively like the viper based loader of earlier
+versions did
+*/
+func (l LegacyConfigCredentials) read() (legacyConfig, error) {
+	var config legacyConfig
+	filename := l.Path
+	if filename == "" {
+		filename = LegacyConfigFile
+	}
+	b, err := ioutil.ReadFile(expandHome(filename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, errors.Wrap(err, "error while reading legacy config file")
+	}
+	var values map[string]interface{}
+	err = yaml.Unmarshal(b, &values)
+	if err != nil {
+		return config, errors.Wrap(err, "error while parsing legacy config file "+filename)
+	}
+	for key, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "baseurl":
+			config.BaseURL = s
+		case "username":
+			config.Username = s
+		case "password":
+			config.Password = s
+		}
+	}
+	return config, nil
+}
+
+/*
+NetrcCredentials reads the credentials of the profile's host from a netrc file. Path defaults to $NETRC or ~/.netrc.
+*/
+type NetrcCredentials struct {
+	Path string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (n NetrcCredentials) Credentials(profile Profile) (Credentials, error) {
+	filename := n.Path
+	if filename == "" {
+		filename = os.Getenv("NETRC")
+	}
+	if filename == "" {
+		filename = "~/.netrc"
+	}
+	u, err := url.Parse(profile.BaseURL)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "invalid base url")
+	}
+	b, err := ioutil.ReadFile(expandHome(filename))
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "error while reading netrc file")
+	}
+	credentials, ok := parseNetrc(b, u.Hostname())
+	if !ok {
+		return credentials, errors.New("netrc file contains no entry for " + u.Hostname())
+	}
+	return credentials, nil
+}
+
+/*
+KeyringFileCredentials reads the credentials from a keyring file, a yaml file which maps profile names to usernames
+and passwords. The file must not be accessible by other users.
+
+	lab1:
+	  username: admin
+	  password: eve
+*/
+type KeyringFileCredentials struct {
+	Path string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (k KeyringFileCredentials) Credentials(profile Profile) (Credentials, error) {
+	filename := expandHome(k.Path)
+	info, err := os.Stat(filename)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "error while reading keyring file")
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return Credentials{}, errors.New("keyring file " + filename + " is accessible by other users")
+	}
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "error while reading keyring file")
+	}
+	var keyring map[string]Credentials
+	err = yaml.UnmarshalStrict(b, &keyring)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "error while parsing keyring file")
+	}
+	credentials, ok := keyring[profile.Name]
+	if !ok {
+		return credentials, errors.New("keyring file contains no entry for profile '" + profile.Name + "'")
+	}
+	return credentials, nil
+}
+
+/*
+CommandCredentials runs a command and reads the credentials from its output, e.g. from a password manager like pass.
+The first line of the output is the password, a later "login: <username>" or "username: <username>" line sets the
+username. Arguments containing {profile} get the profile name substituted.
+*/
+type CommandCredentials struct {
+	Command []string
+}
+
+/*
+Credentials implements CredentialProvider
+*/
+func (c CommandCredentials) Credentials(profile Profile) (Credentials, error) {
+	if len(c.Command) == 0 {
+		return Credentials{}, errors.New("no command given")
+	}
+	args := make([]string, len(c.Command))
+	for i, arg := range c.Command {
+		args[i] = strings.Replace(arg, "{profile}", profile.Name, -1)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "credential command "+args[0]+" failed: "+strings.TrimSpace(stderr.String()))
+	}
+	return parseCommandOutput(output), nil
+}
+
+//---------- helper functions ----------//
+
+/*
+envName - Converts a profile name into the form used in environment variable names
+*/
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return '_'
+		}
+		return unicode.ToUpper(r)
+	}, name)
+}
+
+/*
+expandHome - Replaces a leading ~ by the home directory of the user
+*/
+func expandHome(filename string) string {
+	if filename != "~" && !strings.HasPrefix(filename, "~/") {
+		return filename
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filename
+	}
+	return filepath.Join(home, filename[1:])
+}
+
+/*
+parseNetrc - Returns the credentials of a machine from netrc file content, the default entry is used if no machine
+entry matches
+*/
+func parseNetrc(data []byte, host string) (Credentials, bool) {
+	var current, fallback Credentials
+	var inMachine, inDefault, foundDefault bool
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Split(bufio.ScanWords)
+	next := func() string {
+		if scanner.Scan() {
+			return scanner.Text()
+		}
+		return ""
+	}
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			if inMachine {
+				return current, true
+			}
+			inDefault = false
+			inMachine = next() == host
+		case "default":
+			if inMachine {
+				return current, true
+			}
+			inDefault = true
+			foundDefault = true
+		case "login":
+			login := next()
+			if inMachine {
+				current.Username = login
+			} else if inDefault {
+				fallback.Username = login
+			}
+		case "password":
+			password := next()
+			if inMachine {
+				current.Password = password
+			} else if inDefault {
+				fallback.Password = password
+			}
+		case "account":
+			next()
+		case "macdef":
+			// a macro definition ends with an empty line, which is lost when splitting words, so the rest of the
+			// file is skipped
+			if inMachine {
+				return current, true
+			}
+			return fallback, foundDefault
+		}
+	}
+	if inMachine {
+		return current, true
+	}
+	return fallback, foundDefault
+}
+
+/*
+parseCommandOutput - Parses the output of a credential command
+*/
+func parseCommandOutput(output []byte) Credentials {
+	var credentials Credentials
+	lines := strings.Split(strings.Replace(string(output), "\r\n", "\n", -1), "\n")
+	credentials.Password = lines[0]
+	for _, line := range lines[1:] {
+		for _, key := range []string{"login:", "username:", "user:"} {
+			if strings.HasPrefix(strings.ToLower(line), key) {
+				credentials.Username = strings.TrimSpace(line[len(key):])
+			}
+		}
+	}
+	return credentials
+}
@@ -0,0 +1,146 @@
+package evengclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+SetRateLimit limits the requests sent to the server to requestsPerSecond, allowing bursts of up to burst requests.
+Requests exceeding the limit wait until they are allowed. A rate of 0 removes the limit. The limit is shared with all
+clients derived from this client, e.g. by WithCache or WithContext.
+*/
+func (c *client) SetRateLimit(requestsPerSecond float64, burst int) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	if requestsPerSecond < 0 {
+		return errors.New("invalid rate limit")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	c.limits.mutex.Lock()
+	defer c.limits.mutex.Unlock()
+	if requestsPerSecond == 0 {
+		c.limits.bucket = nil
+		return nil
+	}
+	c.limits.bucket = &tokenBucket{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	return nil
+}
+
+/*
+SetMaxConcurrentRequests limits the number of requests in flight at the same time, further requests wait until a
+request finished. A maximum of 0 removes the limit. The limit is shared with all clients derived from this client.
+*/
+func (c *client) SetMaxConcurrentRequests(max int) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	if max < 0 {
+		return errors.New("invalid maximum number of concurrent requests")
+	}
+	c.limits.mutex.Lock()
+	defer c.limits.mutex.Unlock()
+	if max == 0 {
+		c.limits.inFlight = nil
+		return nil
+	}
+	c.limits.inFlight = make(chan struct{}, max)
+	return nil
+}
+
+//---------- helper functions ----------//
+
+/*
+requestLimits - The rate limit and concurrency cap of a client
+*/
+type requestLimits struct {
+	mutex    sync.Mutex
+	bucket   *tokenBucket
+	inFlight chan struct{}
+}
+
+/*
+tokenBucket - A token bucket which is refilled with rate tokens per second up to burst tokens
+*/
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+/*
+acquire - Waits until the limits allow another request, the returned function must be called when the request is done
+*/
+func (l *requestLimits) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	l.mutex.Lock()
+	bucket, inFlight := l.bucket, l.inFlight
+	l.mutex.Unlock()
+
+	if inFlight != nil {
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "canceled while waiting for a free request slot")
+		}
+	}
+	release := func() {
+		if inFlight != nil {
+			<-inFlight
+		}
+	}
+	if bucket != nil {
+		if err := bucket.wait(ctx); err != nil {
+			release()
+			return nil, errors.Wrap(err, "canceled while waiting for the rate limit")
+		}
+	}
+	return release, nil
+}
+
+/*
+wait - Takes a token from the bucket, waiting until one is available or the context is canceled
+*/
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mutex.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	// the token is reserved right away, so concurrent waiters queue up behind each other
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mutex.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mutex.Lock()
+		b.tokens++
+		b.mutex.Unlock()
+		return ctx.Err()
+	}
+}
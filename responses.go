@@ -160,6 +160,13 @@ type Node struct {
 	Configlist interface{} `json:"configlist"`
 }
 
+const (
+	// NodeStatusStopped is the status of a node which is not running
+	NodeStatusStopped = 0
+	// NodeStatusRunning is the status of a running node
+	NodeStatusRunning = 2
+)
+
 /*
 NodeWithID contains information about a node including its id
 */
@@ -195,8 +202,11 @@ type SerialInterfaces []Interface
 Interface basic interface structure
 */
 type Interface struct {
-	Name      string `json:"name"`
-	NetworkID *int   `json:"network_id"`
+	ID                int    `json:"-"`
+	Name              string `json:"name"`
+	NetworkID         *int   `json:"network_id"`
+	RemoteID          int    `json:"remote_id"`
+	RemoteInterfaceID int    `json:"remote_if"`
 }
 
 /*
@@ -253,6 +263,21 @@ type Picture struct {
 	Height int    `json:"height"`
 }
 
+/*
+TextObjects an array containing text objects
+*/
+type TextObjects []TextObject
+
+/*
+TextObject contains information about a text object (label, rectangle or circle) used for annotating a lab
+*/
+type TextObject struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
 /*
 Templates contains information about all templates
 */
@@ -338,7 +363,7 @@ type Icon struct {
 Image contains information about the templates image option
 */
 type Image struct {
-	List interface{} `json:"list"`
+	List ImageList `json:"list"`
 	StringValTemplateOption
 }
 
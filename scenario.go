@@ -0,0 +1,445 @@
+package evengclient
+
+import (
+	"context"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+ScenarioAction is an action of a scenario step
+*/
+type ScenarioAction string
+
+const (
+	// ScenarioActionSuspendLink suspends the link of a node interface
+	ScenarioActionSuspendLink ScenarioAction = "suspend-link"
+	// ScenarioActionResumeLink resumes the link of a node interface
+	ScenarioActionResumeLink ScenarioAction = "resume-link"
+	// ScenarioActionImpairLink sets delay, jitter, loss and bandwidth of a node interface
+	ScenarioActionImpairLink ScenarioAction = "impair-link"
+	// ScenarioActionClearImpairment restores the link quality a node interface had before the scenario impaired it
+	ScenarioActionClearImpairment ScenarioAction = "clear-impairment"
+	// ScenarioActionStopNode stops a node
+	ScenarioActionStopNode ScenarioAction = "stop-node"
+	// ScenarioActionStartNode starts a node
+	ScenarioActionStartNode ScenarioAction = "start-node"
+	// ScenarioActionRestartNode stops and starts a node
+	ScenarioActionRestartNode ScenarioAction = "restart-node"
+	// ScenarioActionWait waits for the given duration
+	ScenarioActionWait ScenarioAction = "wait"
+)
+
+/*
+ScenarioRollbackTimeout is the time the rollback of a scenario may take
+*/
+const ScenarioRollbackTimeout = 2 * time.Minute
+
+/*
+Scenario is a script of timed steps which inject and remove faults in a running lab
+*/
+type Scenario struct {
+	Name  string         `yaml:"name"`
+	Lab   LabPath        `yaml:"lab"`
+	Steps []ScenarioStep `yaml:"steps"`
+}
+
+/*
+ScenarioStep is a single step of a scenario. After is waited before the step is executed. Nodes are given by name
+or id, interfaces by name.
+*/
+type ScenarioStep struct {
+	Name      string         `yaml:"name"`
+	Action    ScenarioAction `yaml:"action"`
+	After     time.Duration  `yaml:"after"`
+	Duration  time.Duration  `yaml:"duration"`
+	Node      string         `yaml:"node"`
+	Interface string         `yaml:"interface"`
+	Delay     int            `yaml:"delay"`
+	Jitter    int            `yaml:"jitter"`
+	Loss      int            `yaml:"loss"`
+	Bandwidth int            `yaml:"bandwidth"`
+}
+
+/*
+ScenarioLogger is used by the scenario runner to log every step, *log.Logger satisfies it
+*/
+type ScenarioLogger interface {
+	Printf(format string, v ...interface{})
+}
+
+/*
+ScenarioError is returned when a scenario step failed or the scenario was canceled
+*/
+type ScenarioError struct {
+	Step           int
+	Err            error
+	RollbackErrors []error
+}
+
+func (e *ScenarioError) Error() string {
+	msg := "scenario failed: " + e.Err.Error()
+	if e.Step >= 0 {
+		msg = "scenario step " + strconv.Itoa(e.Step+1) + " failed: " + e.Err.Error()
+	}
+	if len(e.RollbackErrors) > 0 {
+		rollbackErrors := make([]string, 0, len(e.RollbackErrors))
+		for _, err := range e.RollbackErrors {
+			rollbackErrors = append(rollbackErrors, err.Error())
+		}
+		msg += " // rollback failed: " + strings.Join(rollbackErrors, "; ")
+	}
+	return msg
+}
+
+/*
+Cause returns the error which made the scenario fail
+*/
+func (e *ScenarioError) Cause() error {
+	return e.Err
+}
+
+/*
+ReadScenarioFile reads and parses a yaml scenario file
+*/
+func ReadScenarioFile(filename string) (Scenario, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Scenario{}, errors.Wrap(err, "error while reading file")
+	}
+	return ParseScenario(b)
+}
+
+/*
+ParseScenario parses and validates a yaml scenario
+*/
+func ParseScenario(data []byte) (Scenario, error) {
+	var scenario Scenario
+	err := yaml.UnmarshalStrict(data, &scenario)
+	if err != nil {
+		return Scenario{}, errors.Wrap(err, "failed to unmarshal scenario")
+	}
+	err = scenario.Validate()
+	if err != nil {
+		return Scenario{}, err
+	}
+	return scenario, nil
+}
+
+/*
+Validate checks that every step of the scenario has a known action and all fields the action needs
+*/
+func (s Scenario) Validate() error {
+	if s.Lab == "" {
+		return errors.New("scenario has no lab")
+	}
+	if err := s.Lab.Validate(); err != nil {
+		return err
+	}
+	for i, step := range s.Steps {
+		err := step.validate()
+		if err != nil {
+			return errors.Wrap(err, "invalid step "+strconv.Itoa(i+1))
+		}
+	}
+	return nil
+}
+
+/*
+RunScenario executes the steps of a scenario against its lab. Requests are sent with ctx, so canceling it also aborts
+the running step. All faults which are still active when the scenario ends, fails or ctx is canceled are rolled back
+in reverse order, using a fresh context limited to ScenarioRollbackTimeout. logger may be nil.
+*/
+func (c *EveNgClient) RunScenario(ctx context.Context, scenario Scenario, logger ScenarioLogger) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	err := scenario.Validate()
+	if err != nil {
+		return err
+	}
+	run := &scenarioRun{client: c.WithContext(ctx), base: c, lab: scenario.Lab, logger: logger, faults: make(map[string]scenarioFault)}
+	run.logf("starting scenario %q on lab %s", scenario.Name, scenario.Lab)
+
+	nodes, err := run.client.GetNodes(scenario.Lab)
+	if err != nil {
+		return &ScenarioError{Step: -1, Err: errors.Wrap(err, "error while retrieving nodes")}
+	}
+	run.nodes = nodes
+
+	for i, step := range scenario.Steps {
+		err = run.sleep(ctx, step.After)
+		if err == nil {
+			run.logf("step %d/%d: %s", i+1, len(scenario.Steps), step)
+			err = run.execute(ctx, step)
+		}
+		if err != nil {
+			run.logf("step %d/%d failed: %s", i+1, len(scenario.Steps), err)
+			return &ScenarioError{Step: i, Err: err, RollbackErrors: run.rollback()}
+		}
+	}
+	if rollbackErrors := run.rollback(); len(rollbackErrors) > 0 {
+		return &ScenarioError{Step: len(scenario.Steps) - 1, Err: errors.New("scenario finished but faults could not be rolled back"), RollbackErrors: rollbackErrors}
+	}
+	run.logf("scenario %q finished", scenario.Name)
+	return nil
+}
+
+/*
+String describes the step for logging
+*/
+func (s ScenarioStep) String() string {
+	description := string(s.Action)
+	if s.Name != "" {
+		description = s.Name + " (" + description + ")"
+	}
+	switch s.Action {
+	case ScenarioActionWait:
+		return description + " " + s.Duration.String()
+	case ScenarioActionImpairLink:
+		return description + " " + s.Node + ":" + s.Interface + " delay=" + strconv.Itoa(s.Delay) + "ms jitter=" + strconv.Itoa(s.Jitter) + "ms loss=" + strconv.Itoa(s.Loss) + "% bandwidth=" + strconv.Itoa(s.Bandwidth) + "kbit/s"
+	}
+	if s.Interface != "" {
+		return description + " " + s.Node + ":" + s.Interface
+	}
+	return description + " " + s.Node
+}
+
+//---------- helper functions ----------//
+
+/*
+scenarioFault - A fault injected by a scenario together with the function which removes it
+*/
+type scenarioFault struct {
+	description string
+	undo        func(c *EveNgClient) error
+}
+
+/*
+scenarioRun - State of a running scenario
+*/
+type scenarioRun struct {
+	// client sends the requests of the steps with the context of the scenario, base is used for the rollback
+	client *EveNgClient
+	base   *EveNgClient
+	lab    LabPath
+	logger ScenarioLogger
+	nodes  Nodes
+
+	faults     map[string]scenarioFault
+	faultOrder []string
+}
+
+func (s ScenarioStep) validate() error {
+	switch s.Action {
+	case ScenarioActionWait:
+		if s.Duration <= 0 {
+			return errors.New("wait needs a positive duration")
+		}
+		return nil
+	case ScenarioActionStopNode, ScenarioActionStartNode, ScenarioActionRestartNode:
+		if s.Node == "" {
+			return errors.New(string(s.Action) + " needs a node")
+		}
+		return nil
+	case ScenarioActionSuspendLink, ScenarioActionResumeLink, ScenarioActionImpairLink, ScenarioActionClearImpairment:
+		if s.Node == "" || s.Interface == "" {
+			return errors.New(string(s.Action) + " needs a node and an interface")
+		}
+		if s.Action == ScenarioActionImpairLink {
+			return Impairment{Delay: s.Delay, Jitter: s.Jitter, Loss: s.Loss, Bandwidth: s.Bandwidth}.Validate()
+		}
+		return nil
+	}
+	return errors.New("unknown action '" + string(s.Action) + "'")
+}
+
+func (r *scenarioRun) execute(ctx context.Context, step ScenarioStep) error {
+	if step.Action == ScenarioActionWait {
+		return r.sleep(ctx, step.Duration)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	node, err := r.node(step.Node)
+	if err != nil {
+		return err
+	}
+	nodeKey := "node " + node.Name
+
+	switch step.Action {
+	case ScenarioActionStopNode:
+		current, err := r.client.GetNode(r.lab, node.ID)
+		if err != nil {
+			return errors.Wrap(err, "error while retrieving node state")
+		}
+		err = r.client.StopNode(r.lab, node.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status == NodeStatusRunning {
+			r.addFault(nodeKey, "stopped "+nodeKey, func(c *EveNgClient) error {
+				return c.StartNode(r.lab, node.ID)
+			})
+		}
+		return nil
+	case ScenarioActionStartNode:
+		err = r.client.StartNode(r.lab, node.ID)
+		if err == nil {
+			r.removeFault(nodeKey)
+		}
+		return err
+	case ScenarioActionRestartNode:
+		r.addFault(nodeKey, "restarted "+nodeKey, func(c *EveNgClient) error {
+			return c.StartNode(r.lab, node.ID)
+		})
+		err = r.client.StopNode(r.lab, node.ID)
+		if err != nil {
+			return err
+		}
+		err = r.client.StartNode(r.lab, node.ID)
+		if err == nil {
+			r.removeFault(nodeKey)
+		}
+		return err
+	}
+
+	interfaces, err := r.client.GetNodeInterfaces(r.lab, node.ID)
+	if err != nil {
+		return errors.Wrap(err, "error while retrieving node interfaces")
+	}
+	iface, _, err := interfaces.FindInterface(step.Interface)
+	if err != nil {
+		return errors.Wrap(err, nodeKey)
+	}
+	linkKey := nodeKey + " interface " + iface.Name
+
+	switch step.Action {
+	case ScenarioActionSuspendLink:
+		state, err := r.client.GetLinkState(r.lab, node.ID, iface.ID)
+		if err != nil {
+			return err
+		}
+		err = r.client.SuspendLink(r.lab, node.ID, iface.ID)
+		if err != nil {
+			return err
+		}
+		if !state.Suspended {
+			r.addFault("suspend "+linkKey, "suspended link of "+linkKey, func(c *EveNgClient) error {
+				return c.ResumeLink(r.lab, node.ID, iface.ID)
+			})
+		}
+	case ScenarioActionResumeLink:
+		err = r.client.ResumeLink(r.lab, node.ID, iface.ID)
+		if err != nil {
+			return err
+		}
+		r.removeFault("suspend " + linkKey)
+	case ScenarioActionImpairLink:
+		if _, impaired := r.faults["impair "+linkKey]; !impaired {
+			state, err := r.client.GetLinkState(r.lab, node.ID, iface.ID)
+			if err != nil {
+				return err
+			}
+			r.addFault("impair "+linkKey, "impaired link of "+linkKey, func(c *EveNgClient) error {
+				return c.SetLinkImpairment(r.lab, node.ID, iface.ID, state.Impairment)
+			})
+		}
+		return r.client.SetLinkImpairment(r.lab, node.ID, iface.ID, Impairment{Delay: step.Delay, Jitter: step.Jitter, Loss: step.Loss, Bandwidth: step.Bandwidth})
+	case ScenarioActionClearImpairment:
+		fault, impaired := r.faults["impair "+linkKey]
+		if !impaired {
+			return r.client.ClearLinkImpairment(r.lab, node.ID, iface.ID)
+		}
+		err = fault.undo(r.client)
+		if err != nil {
+			return err
+		}
+		r.removeFault("impair " + linkKey)
+	}
+	return nil
+}
+
+/*
+node - Looks up a node of the lab by its name or id
+*/
+func (r *scenarioRun) node(nameOrID string) (NodeWithID, error) {
+	for _, node := range r.nodes {
+		if node.Name == nameOrID {
+			return node, nil
+		}
+	}
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for _, node := range r.nodes {
+			if node.ID == id {
+				return node, nil
+			}
+		}
+	}
+	return NodeWithID{}, errors.New("lab has no node '" + nameOrID + "'")
+}
+
+func (r *scenarioRun) addFault(key string, description string, undo func(c *EveNgClient) error) {
+	if _, ok := r.faults[key]; !ok {
+		r.faultOrder = append(r.faultOrder, key)
+	}
+	r.faults[key] = scenarioFault{description: description, undo: undo}
+}
+
+func (r *scenarioRun) removeFault(key string) {
+	delete(r.faults, key)
+}
+
+/*
+rollback - Removes all active faults in reverse order. It does not use the context of the scenario because faults
+have to be removed even if the scenario was canceled.
+*/
+func (r *scenarioRun) rollback() []error {
+	ctx, cancel := context.WithTimeout(context.Background(), ScenarioRollbackTimeout)
+	defer cancel()
+	client := r.base.WithContext(ctx)
+
+	var rollbackErrors []error
+	for i := len(r.faultOrder) - 1; i >= 0; i-- {
+		fault, ok := r.faults[r.faultOrder[i]]
+		if !ok {
+			continue
+		}
+		r.logf("rolling back: %s", fault.description)
+		err := fault.undo(client)
+		if err != nil {
+			r.logf("rollback of %s failed: %s", fault.description, err)
+			rollbackErrors = append(rollbackErrors, errors.Wrap(err, fault.description))
+			continue
+		}
+		delete(r.faults, r.faultOrder[i])
+	}
+	r.faultOrder = nil
+	return rollbackErrors
+}
+
+func (r *scenarioRun) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *scenarioRun) logf(format string, v ...interface{}) {
+	if r.logger != nil {
+		r.logger.Printf(format, v...)
+	}
+}
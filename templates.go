@@ -0,0 +1,180 @@
+package evengclient
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	maxNodeCPU      = 64
+	maxNodeEthernet = 64
+)
+
+/*
+NodeSpecError is a validation error of a single NodeSpec field
+*/
+type NodeSpecError struct {
+	Field   string
+	Value   string
+	Message string
+}
+
+func (e *NodeSpecError) Error() string {
+	return "invalid " + e.Field + " '" + e.Value + "': " + e.Message
+}
+
+/*
+NodeSpecErrors contains all validation errors of a NodeSpec
+*/
+type NodeSpecErrors []*NodeSpecError
+
+func (e NodeSpecErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "invalid node spec: " + strings.Join(messages, "; ")
+}
+
+/*
+ValidateNodeSpec fetches the template of a node spec and validates the spec against it, see Template.ValidateNodeSpec
+*/
+func (c *EveNgClient) ValidateNodeSpec(spec NodeSpec) (NodeSpec, error) {
+	if !c.isValid() {
+		return spec, &NotValidError{}
+	}
+	if spec.Template == "" {
+		return spec, NodeSpecErrors{{Field: "template", Message: "no template given"}}
+	}
+	template, err := c.GetNodeTemplate(spec.Template)
+	if err != nil {
+		return spec, errors.Wrap(err, "error while retrieving template "+spec.Template)
+	}
+	return template.ValidateNodeSpec(spec)
+}
+
+/*
+AddNodeFromSpec validates a node spec against its template and adds the node to a lab. It returns the id of the
+first added node.
+*/
+func (c *EveNgClient) AddNodeFromSpec(labPath LabPath, spec NodeSpec) (int, error) {
+	spec, err := c.ValidateNodeSpec(spec)
+	if err != nil {
+		return 0, err
+	}
+	return c.AddNode(labPath, spec.Type, spec.Template, "0", spec.Delay, spec.Icon, spec.Image, spec.Name, spec.Left, spec.Top, spec.RAM, spec.Console, spec.CPU, "undefined", spec.Ethernet, "", "", "", "", spec.Count)
+}
+
+/*
+ValidateNodeSpec fills the unset fields of a node spec with the defaults of the template and checks the result. An
+image must be installed for the template, console and icon must be offered by the template and numeric values must
+be in range. All invalid fields are returned as NodeSpecErrors.
+*/
+func (t Template) ValidateNodeSpec(spec NodeSpec) (NodeSpec, error) {
+	options := t.Options
+	if spec.Type == "" {
+		spec.Type = t.Type
+	}
+	if spec.Name == "" {
+		spec.Name = options.Name.Value
+	}
+	if spec.Image == "" {
+		spec.Image = options.Image.Value
+	}
+	if spec.Icon == "" {
+		spec.Icon = options.Icon.Value
+	}
+	if spec.Console == "" {
+		spec.Console = options.Console.Value
+	}
+	if spec.RAM == 0 {
+		spec.RAM = options.RAM.Value
+	}
+	if spec.CPU == 0 {
+		spec.CPU = options.CPU.Value
+	}
+	if spec.Ethernet == 0 {
+		spec.Ethernet = options.Ethernet.Value
+	}
+	if spec.Delay == 0 {
+		spec.Delay = options.Delay.Value
+	}
+	if spec.Count == 0 {
+		spec.Count = 1
+	}
+
+	var errs NodeSpecErrors
+	invalid := func(field, value, message string) {
+		errs = append(errs, &NodeSpecError{Field: field, Value: value, Message: message})
+	}
+	if spec.Type != t.Type {
+		invalid("type", spec.Type, "template "+spec.Template+" is of type "+t.Type)
+	}
+	if spec.Name == "" {
+		invalid("name", spec.Name, "name is empty")
+	}
+	if options.Image.Name != "" {
+		images := options.Image.List
+		if len(images) == 0 {
+			invalid("image", spec.Image, (&NoImagesInstalledError{Template: spec.Template}).Error())
+		} else if !images.Contains(spec.Image) {
+			invalid("image", spec.Image, "image is not installed, installed images: "+strings.Join(images, ", "))
+		}
+	}
+	if len(options.Console.List) > 0 && !options.Console.List.contains(spec.Console) {
+		invalid("console", spec.Console, "console type is not supported, supported types: "+strings.Join(options.Console.List.keys(), ", "))
+	}
+	if len(options.Icon.List) > 0 && !options.Icon.List.contains(spec.Icon) {
+		invalid("icon", spec.Icon, "icon does not exist")
+	}
+	if options.RAM.Name != "" && spec.RAM < 1 {
+		invalid("ram", strconv.Itoa(spec.RAM), "ram must be at least 1 MB")
+	}
+	if options.CPU.Name != "" && (spec.CPU < 1 || spec.CPU > maxNodeCPU) {
+		invalid("cpu", strconv.Itoa(spec.CPU), "cpu must be between 1 and "+strconv.Itoa(maxNodeCPU))
+	}
+	if spec.Ethernet < 0 || spec.Ethernet > maxNodeEthernet {
+		invalid("ethernet", strconv.Itoa(spec.Ethernet), "ethernet must be between 0 and "+strconv.Itoa(maxNodeEthernet))
+	}
+	if spec.Delay < 0 {
+		invalid("delay", strconv.Itoa(spec.Delay), "delay must not be negative")
+	}
+	if spec.Left < 0 {
+		invalid("left", strconv.Itoa(spec.Left), "position must not be negative")
+	}
+	if spec.Top < 0 {
+		invalid("top", strconv.Itoa(spec.Top), "position must not be negative")
+	}
+	if spec.Count < 1 {
+		invalid("count", strconv.Itoa(spec.Count), "count must be at least 1")
+	}
+	if len(errs) > 0 {
+		return spec, errs
+	}
+	return spec, nil
+}
+
+//---------- helper functions ----------//
+
+/*
+contains - Checks whether the list contains the given key
+*/
+func (l List) contains(key string) bool {
+	_, ok := l[key]
+	return ok
+}
+
+/*
+keys - Returns the sorted keys of the list
+*/
+func (l List) keys() []string {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,130 @@
+package evengclient
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+TextShapeKind is the kind of a text object as drawn by the eve-ng web interface
+*/
+type TextShapeKind string
+
+const (
+	// TextShapeText is a text label
+	TextShapeText TextShapeKind = "text"
+	// TextShapeSquare is a rectangle, e.g. for drawing site boundaries
+	TextShapeSquare TextShapeKind = "square"
+	// TextShapeCircle is a circle or ellipse
+	TextShapeCircle TextShapeKind = "circle"
+)
+
+/*
+TextShape describes a text object which is rendered into the html the eve-ng web interface expects. Colors are css
+colors, zero values are replaced by the defaults of the web interface.
+*/
+type TextShape struct {
+	Kind            TextShapeKind
+	Text            string
+	Left            int
+	Top             int
+	Width           int
+	Height          int
+	FontSize        int
+	Color           string
+	BackgroundColor string
+	BorderColor     string
+	BorderWidth     int
+}
+
+/*
+UnmarshalJSON - Decodes text objects which eve-ng returns as object keyed by text object id
+*/
+func (t *TextObjects) UnmarshalJSON(data []byte) error {
+	var list []TextObject
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = list
+		return nil
+	}
+	var byID map[string]TextObject
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return errors.Wrap(err, "text objects are neither a list nor an object")
+	}
+	list = make([]TextObject, 0, len(byID))
+	for key, textObject := range byID {
+		if textObject.ID == 0 {
+			textObject.ID, _ = strconv.Atoi(key)
+		}
+		list = append(list, textObject)
+	}
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].ID < list[b].ID
+	})
+	*t = list
+	return nil
+}
+
+/*
+HTML renders the shape for the text object with the given id
+*/
+func (s TextShape) HTML(id int) string {
+	if s.BorderColor == "" {
+		s.BorderColor = "#000000"
+	}
+	if s.BorderWidth == 0 {
+		s.BorderWidth = 5
+	}
+	if s.Color == "" {
+		s.Color = "#000000"
+	}
+	if s.FontSize == 0 {
+		s.FontSize = 12
+	}
+	position := "display: inline; position: absolute; left: " + strconv.Itoa(s.Left) + "px; top: " + strconv.Itoa(s.Top) + "px; cursor: move;"
+	idString := strconv.Itoa(id)
+
+	switch s.Kind {
+	case TextShapeSquare, TextShapeCircle:
+		if s.Width == 0 {
+			s.Width = 120
+		}
+		if s.Height == 0 {
+			s.Height = 120
+		}
+		fill, fillOpacity := s.BackgroundColor, "1"
+		if fill == "" {
+			fill, fillOpacity = "#ffffff", "0"
+		}
+		width, height := strconv.Itoa(s.Width), strconv.Itoa(s.Height)
+		element, geometry := "rect", `width="`+width+`" height="`+height+`"`
+		if s.Kind == TextShapeCircle {
+			element = "ellipse"
+			geometry = `cx="` + strconv.Itoa(s.Width/2) + `" cy="` + strconv.Itoa(s.Height/2) + `" rx="` + strconv.Itoa(s.Width/2-s.BorderWidth/2) + `" ry="` + strconv.Itoa(s.Height/2-s.BorderWidth/2) + `"`
+		}
+		shape := `<` + element + ` ` + geometry + ` fill="` + html.EscapeString(fill) + `" fill-opacity="` + fillOpacity + `" stroke="` + html.EscapeString(s.BorderColor) + `" stroke-width="` + strconv.Itoa(s.BorderWidth) + `"></` + element + `>`
+		return `<div id="customShape` + idString + `" class="customShape context-menu" data-path="` + idString + `" style="` + position + ` z-index: 999; width: ` + width + `px; height: ` + height + `px;">` +
+			`<svg width="` + width + `" height="` + height + `">` + shape + `</svg></div>`
+	}
+
+	background := s.BackgroundColor
+	if background == "" {
+		background = "transparent"
+	}
+	return `<div id="customText` + idString + `" class="customShape customText context-menu" data-path="` + idString + `" style="` + position + ` z-index: 1001;">` +
+		`<p align="center" style="vertical-align: top; color: ` + html.EscapeString(s.Color) + `; background-color: ` + html.EscapeString(background) + `; font-size: ` + strconv.Itoa(s.FontSize) + `px; font-weight: normal;">` +
+		html.EscapeString(s.Text) + `</p></div>`
+}
+
+/*
+objectType - Returns the text object type stored by eve-ng for the shape
+*/
+func (s TextShape) objectType() string {
+	if s.Kind == "" {
+		return string(TextShapeText)
+	}
+	return string(s.Kind)
+}
@@ -0,0 +1,383 @@
+package evengclient
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+VertexKind distinguishes the two kinds of vertices in a lab topology graph
+*/
+type VertexKind string
+
+const (
+	// VertexKindNode marks a vertex representing a lab node
+	VertexKindNode VertexKind = "node"
+	// VertexKindNetwork marks a vertex representing a lab network
+	VertexKindNetwork VertexKind = "network"
+)
+
+/*
+Vertex identifies a node or a network inside a topology graph
+*/
+type Vertex struct {
+	Kind VertexKind
+	ID   int
+}
+
+/*
+String returns the vertex in the notation used by the eve-ng topology api, e.g. "node1" or "network3"
+*/
+func (v Vertex) String() string {
+	return string(v.Kind) + strconv.Itoa(v.ID)
+}
+
+/*
+Endpoint is one end of a link. InterfaceID and InterfaceLabel are only set for node endpoints.
+*/
+type Endpoint struct {
+	Vertex
+	InterfaceID    int
+	InterfaceLabel string
+}
+
+/*
+Link is a connection between two vertices as reported by the eve-ng topology api
+*/
+type Link struct {
+	Type        string
+	Source      Endpoint
+	Destination Endpoint
+	NetworkID   int
+	Label       string
+	Point       Topology
+}
+
+/*
+GraphNode is a node vertex of a topology graph
+*/
+type GraphNode struct {
+	NodeWithID
+	Links []*Link
+}
+
+/*
+GraphNetwork is a network vertex of a topology graph
+*/
+type GraphNetwork struct {
+	NetworkWithID
+	Links []*Link
+}
+
+/*
+Graph is a typed model of a lab topology which allows reasoning about lab connectivity
+*/
+type Graph struct {
+	Nodes    map[int]*GraphNode
+	Networks map[int]*GraphNetwork
+	Links    []*Link
+}
+
+/*
+GetTopologyGraph builds the topology graph of the given lab from its topology, nodes and networks
+*/
+func (c *EveNgClient) GetTopologyGraph(labPath LabPath) (*Graph, error) {
+	nodes, err := c.GetNodes(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving nodes")
+	}
+	networks, err := c.GetNetworks(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving networks")
+	}
+	topology, err := c.GetTopology(labPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while retrieving topology")
+	}
+	return NewGraph(nodes, networks, topology)
+}
+
+/*
+NewGraph builds a topology graph from the results of GetNodes, GetNetworks and GetTopology
+*/
+func NewGraph(nodes Nodes, networks Networks, topology TopologyPoints) (*Graph, error) {
+	graph := &Graph{
+		Nodes:    make(map[int]*GraphNode, len(nodes)),
+		Networks: make(map[int]*GraphNetwork, len(networks)),
+	}
+	for _, node := range nodes {
+		graph.Nodes[node.ID] = &GraphNode{NodeWithID: node}
+	}
+	for _, network := range networks {
+		graph.Networks[network.ID] = &GraphNetwork{NetworkWithID: network}
+	}
+
+	for _, point := range topology {
+		source, err := parseVertex(point.Source)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid topology source")
+		}
+		destination, err := parseVertex(point.Destination)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid topology destination")
+		}
+		link := &Link{
+			Type:        point.Type,
+			Source:      Endpoint{Vertex: source},
+			Destination: Endpoint{Vertex: destination},
+			NetworkID:   point.NetworkID,
+			Label:       point.Label,
+			Point:       point,
+		}
+		if source.Kind == VertexKindNode {
+			link.Source.InterfaceID = point.SourceInterfaceID
+			link.Source.InterfaceLabel = point.SourceLabel
+		}
+		if destination.Kind == VertexKindNode {
+			// the destination interface id is returned as string and may be empty
+			link.Destination.InterfaceID, _ = strconv.Atoi(point.DestinationInterfaceID)
+			link.Destination.InterfaceLabel = point.DestinationLabel
+		}
+		graph.Links = append(graph.Links, link)
+		graph.attach(source, link)
+		graph.attach(destination, link)
+	}
+	return graph, nil
+}
+
+/*
+Vertices returns all vertices of the graph, sorted by kind and id
+*/
+func (g *Graph) Vertices() []Vertex {
+	vertices := make([]Vertex, 0, len(g.Nodes)+len(g.Networks))
+	for id := range g.Nodes {
+		vertices = append(vertices, Vertex{Kind: VertexKindNode, ID: id})
+	}
+	for id := range g.Networks {
+		vertices = append(vertices, Vertex{Kind: VertexKindNetwork, ID: id})
+	}
+	sortVertices(vertices)
+	return vertices
+}
+
+/*
+LinksOf returns all links attached to the given vertex
+*/
+func (g *Graph) LinksOf(v Vertex) []*Link {
+	switch v.Kind {
+	case VertexKindNode:
+		if node, ok := g.Nodes[v.ID]; ok {
+			return node.Links
+		}
+	case VertexKindNetwork:
+		if network, ok := g.Networks[v.ID]; ok {
+			return network.Links
+		}
+	}
+	return nil
+}
+
+/*
+Adjacent returns all vertices directly connected to the given vertex
+*/
+func (g *Graph) Adjacent(v Vertex) []Vertex {
+	seen := make(map[Vertex]bool)
+	var adjacent []Vertex
+	for _, link := range g.LinksOf(v) {
+		other := link.Destination.Vertex
+		if other == v {
+			other = link.Source.Vertex
+		}
+		if other == v || seen[other] {
+			continue
+		}
+		seen[other] = true
+		adjacent = append(adjacent, other)
+	}
+	sortVertices(adjacent)
+	return adjacent
+}
+
+/*
+NeighborsOf returns the ids of all nodes which share a network or a serial link with the given node
+*/
+func (g *Graph) NeighborsOf(nodeID int) []int {
+	self := Vertex{Kind: VertexKindNode, ID: nodeID}
+	seen := map[int]bool{nodeID: true}
+	var neighbors []int
+	add := func(v Vertex) {
+		if v.Kind == VertexKindNode && !seen[v.ID] {
+			seen[v.ID] = true
+			neighbors = append(neighbors, v.ID)
+		}
+	}
+	for _, v := range g.Adjacent(self) {
+		if v.Kind == VertexKindNetwork {
+			for _, behindNetwork := range g.Adjacent(v) {
+				add(behindNetwork)
+			}
+			continue
+		}
+		add(v)
+	}
+	sort.Ints(neighbors)
+	return neighbors
+}
+
+/*
+PathBetween returns the shortest path between two vertices including both of them. It returns nil if the
+vertices are not connected.
+*/
+func (g *Graph) PathBetween(from, to Vertex) []Vertex {
+	if !g.hasVertex(from) || !g.hasVertex(to) {
+		return nil
+	}
+	previous := map[Vertex]Vertex{from: from}
+	queue := []Vertex{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []Vertex
+			for v := to; v != from; v = previous[v] {
+				path = append([]Vertex{v}, path...)
+			}
+			return append([]Vertex{from}, path...)
+		}
+		for _, next := range g.Adjacent(current) {
+			if _, visited := previous[next]; !visited {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ConnectedComponents returns all sets of vertices that are connected to each other, the largest component first
+*/
+func (g *Graph) ConnectedComponents() [][]Vertex {
+	visited := make(map[Vertex]bool)
+	var components [][]Vertex
+	for _, start := range g.Vertices() {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		component := []Vertex{start}
+		for i := 0; i < len(component); i++ {
+			for _, next := range g.Adjacent(component[i]) {
+				if !visited[next] {
+					visited[next] = true
+					component = append(component, next)
+				}
+			}
+		}
+		sortVertices(component)
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(a, b int) bool {
+		return len(components[a]) > len(components[b])
+	})
+	return components
+}
+
+/*
+DanglingInterfaces returns all node endpoints that do not lead anywhere: interfaces attached to a network without
+any other attachment and interfaces linked to a vertex which is unknown to the graph
+*/
+func (g *Graph) DanglingInterfaces() []Endpoint {
+	var dangling []Endpoint
+	for _, link := range g.Links {
+		for _, pair := range [][2]Endpoint{{link.Source, link.Destination}, {link.Destination, link.Source}} {
+			local, remote := pair[0], pair[1]
+			if local.Kind != VertexKindNode {
+				continue
+			}
+			if !g.hasVertex(remote.Vertex) || (remote.Kind == VertexKindNetwork && len(g.LinksOf(remote.Vertex)) < 2) {
+				dangling = append(dangling, local)
+			}
+		}
+	}
+	sort.Slice(dangling, func(a, b int) bool {
+		if dangling[a].ID != dangling[b].ID {
+			return dangling[a].ID < dangling[b].ID
+		}
+		return dangling[a].InterfaceID < dangling[b].InterfaceID
+	})
+	return dangling
+}
+
+/*
+IsolatedNodes returns the ids of all nodes without any link
+*/
+func (g *Graph) IsolatedNodes() []int {
+	var isolated []int
+	for id, node := range g.Nodes {
+		if len(node.Links) == 0 {
+			isolated = append(isolated, id)
+		}
+	}
+	sort.Ints(isolated)
+	return isolated
+}
+
+//---------- helper functions ----------//
+
+/*
+attach - Adds a link to the link list of the given vertex if the vertex is known to the graph
+*/
+func (g *Graph) attach(v Vertex, link *Link) {
+	switch v.Kind {
+	case VertexKindNode:
+		if node, ok := g.Nodes[v.ID]; ok {
+			node.Links = append(node.Links, link)
+		}
+	case VertexKindNetwork:
+		if network, ok := g.Networks[v.ID]; ok {
+			network.Links = append(network.Links, link)
+		}
+	}
+}
+
+func (g *Graph) hasVertex(v Vertex) bool {
+	switch v.Kind {
+	case VertexKindNode:
+		_, ok := g.Nodes[v.ID]
+		return ok
+	case VertexKindNetwork:
+		_, ok := g.Networks[v.ID]
+		return ok
+	}
+	return false
+}
+
+/*
+parseVertex - Parses a vertex in the notation used by the eve-ng topology api, e.g. "node1" or "network3"
+*/
+func parseVertex(s string) (Vertex, error) {
+	for _, kind := range []VertexKind{VertexKindNetwork, VertexKindNode} {
+		if !strings.HasPrefix(s, string(kind)) {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(s, string(kind)))
+		if err != nil {
+			return Vertex{}, errors.Wrap(err, "invalid "+string(kind)+" id in '"+s+"'")
+		}
+		return Vertex{Kind: kind, ID: id}, nil
+	}
+	return Vertex{}, errors.New("unknown vertex '" + s + "'")
+}
+
+func sortVertices(vertices []Vertex) {
+	sort.Slice(vertices, func(a, b int) bool {
+		if vertices[a].Kind != vertices[b].Kind {
+			return vertices[a].Kind == VertexKindNode
+		}
+		return vertices[a].ID < vertices[b].ID
+	})
+}
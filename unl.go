@@ -0,0 +1,373 @@
+package evengclient
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+UNLLab is the root element of a native eve-ng .unl lab file.
+
+Attributes which are not modeled explicitly are kept in Attrs, so parsing and serializing a lab file does not lose
+information.
+*/
+type UNLLab struct {
+	XMLName       xml.Name      `xml:"lab"`
+	Name          string        `xml:"name,attr"`
+	ID            string        `xml:"id,attr,omitempty"`
+	Version       string        `xml:"version,attr,omitempty"`
+	ScriptTimeout int           `xml:"scripttimeout,attr,omitempty"`
+	Lock          int           `xml:"lock,attr,omitempty"`
+	Author        string        `xml:"author,attr,omitempty"`
+	Attrs         []xml.Attr    `xml:",any,attr"`
+	Description   string        `xml:"description,omitempty"`
+	Body          string        `xml:"body,omitempty"`
+	Topology      UNLTopology   `xml:"topology"`
+	Objects       *UNLObjects   `xml:"objects,omitempty"`
+	Extra         []UNLAnyChild `xml:",any"`
+}
+
+/*
+UNLTopology contains the nodes and networks of a lab file
+*/
+type UNLTopology struct {
+	Nodes    []UNLNode    `xml:"nodes>node"`
+	Networks []UNLNetwork `xml:"networks>network"`
+}
+
+/*
+UNLNode is a node of a lab file
+*/
+type UNLNode struct {
+	ID         int            `xml:"id,attr"`
+	Name       string         `xml:"name,attr"`
+	Type       string         `xml:"type,attr"`
+	Template   string         `xml:"template,attr"`
+	Image      string         `xml:"image,attr"`
+	Console    string         `xml:"console,attr,omitempty"`
+	CPU        int            `xml:"cpu,attr,omitempty"`
+	CPULimit   int            `xml:"cpulimit,attr,omitempty"`
+	RAM        int            `xml:"ram,attr,omitempty"`
+	Ethernet   int            `xml:"ethernet,attr,omitempty"`
+	Serial     int            `xml:"serial,attr,omitempty"`
+	UUID       string         `xml:"uuid,attr,omitempty"`
+	Firstmac   string         `xml:"firstmac,attr,omitempty"`
+	Delay      int            `xml:"delay,attr"`
+	Icon       string         `xml:"icon,attr,omitempty"`
+	Config     string         `xml:"config,attr"`
+	Left       int            `xml:"left,attr"`
+	Top        int            `xml:"top,attr"`
+	Attrs      []xml.Attr     `xml:",any,attr"`
+	Interfaces []UNLInterface `xml:"interface"`
+}
+
+/*
+UNLInterface is a node interface of a lab file. Ethernet interfaces reference a network, serial interfaces
+reference an interface of a remote node.
+*/
+type UNLInterface struct {
+	ID        int    `xml:"id,attr"`
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	NetworkID int    `xml:"network_id,attr,omitempty"`
+	RemoteID  int    `xml:"remote_id,attr,omitempty"`
+	RemoteIf  int    `xml:"remote_if,attr,omitempty"`
+}
+
+/*
+UNLNetwork is a network of a lab file
+*/
+type UNLNetwork struct {
+	ID         int        `xml:"id,attr"`
+	Type       string     `xml:"type,attr"`
+	Name       string     `xml:"name,attr"`
+	Left       int        `xml:"left,attr"`
+	Top        int        `xml:"top,attr"`
+	Visibility int        `xml:"visibility,attr"`
+	Attrs      []xml.Attr `xml:",any,attr"`
+}
+
+/*
+UNLObjects contains the text objects, pictures and startup configs of a lab file
+*/
+type UNLObjects struct {
+	TextObjects []UNLTextObject `xml:"textobjects>textobject,omitempty"`
+	Pictures    []UNLPicture    `xml:"pictures>picture,omitempty"`
+	Configs     []UNLConfig     `xml:"configs>config,omitempty"`
+}
+
+/*
+UNLTextObject is a text object of a lab file, its data is base64 encoded html
+*/
+type UNLTextObject struct {
+	ID    int        `xml:"id,attr"`
+	Name  string     `xml:"name,attr"`
+	Type  string     `xml:"type,attr"`
+	Data  string     `xml:"data"`
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+/*
+UNLPicture is a picture of a lab file, its data is the base64 encoded image
+*/
+type UNLPicture struct {
+	ID     int        `xml:"id,attr"`
+	Name   string     `xml:"name,attr"`
+	Type   string     `xml:"type,attr"`
+	Width  int        `xml:"width,attr,omitempty"`
+	Height int        `xml:"height,attr,omitempty"`
+	Attrs  []xml.Attr `xml:",any,attr"`
+	Data   string     `xml:"data"`
+	Map    string     `xml:"map"`
+}
+
+/*
+UNLConfig is the base64 encoded startup config of the node with the given id
+*/
+type UNLConfig struct {
+	ID   int    `xml:"id,attr"`
+	Data string `xml:",chardata"`
+}
+
+/*
+UNLAnyChild keeps unknown elements of a lab file
+*/
+type UNLAnyChild struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+/*
+ParseUNL parses the content of a .unl lab file
+*/
+func ParseUNL(data []byte) (*UNLLab, error) {
+	var lab UNLLab
+	err := xml.Unmarshal(data, &lab)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal lab file")
+	}
+	return &lab, nil
+}
+
+/*
+Marshal returns the lab in the .unl format
+*/
+func (l *UNLLab) Marshal() ([]byte, error) {
+	b, err := xml.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal lab file")
+	}
+	return append([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"), append(b, '\n')...), nil
+}
+
+/*
+NodeConfig returns the decoded startup config of the given node and false if the node has no startup config
+*/
+func (l *UNLLab) NodeConfig(nodeID int) (string, bool, error) {
+	if l.Objects == nil {
+		return "", false, nil
+	}
+	for _, config := range l.Objects.Configs {
+		if config.ID != nodeID {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(config.Data))
+		if err != nil {
+			return "", true, errors.Wrap(err, "failed to decode startup config")
+		}
+		return string(b), true, nil
+	}
+	return "", false, nil
+}
+
+/*
+SetNodeConfig sets the startup config of the given node
+*/
+func (l *UNLLab) SetNodeConfig(nodeID int, config string) {
+	if l.Objects == nil {
+		l.Objects = &UNLObjects{}
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(config))
+	for i := range l.Objects.Configs {
+		if l.Objects.Configs[i].ID == nodeID {
+			l.Objects.Configs[i].Data = encoded
+			return
+		}
+	}
+	l.Objects.Configs = append(l.Objects.Configs, UNLConfig{ID: nodeID, Data: encoded})
+}
+
+//---------- Lab file operations ----------//
+
+/*
+ExportLabs exports the given labs and folders using the eve-ng zip export and returns the zip archive
+*/
+func (c *EveNgClient) ExportLabs(folder FolderPath, paths ...string) ([]byte, error) {
+	if !c.isValid() {
+		return nil, &NotValidError{}
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no labs to export given")
+	}
+	httpBody := make(map[string]string)
+	httpBody["path"] = folder.String()
+	for i, p := range paths {
+		httpBody[strconv.Itoa(i)] = p
+	}
+	b, err := json.Marshal(httpBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal http body to json")
+	}
+	response, err := c.request("POST", endpointPath+"export", string(b), nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while exporting labs from folder "+folder.String())
+	}
+	var exportFile string
+	err = c.unmarshalDataIntoStruct(response.Body(), &exportFile)
+	if err != nil {
+		return nil, err
+	}
+	if exportFile == "" {
+		return nil, errors.New("export did not return a file")
+	}
+	response, err = c.request("GET", strings.TrimPrefix(exportFile, "/"), "", nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while downloading export file")
+	}
+	return response.Body(), nil
+}
+
+/*
+ImportLabs imports a zip archive containing labs and folders into the given folder using the eve-ng zip import
+*/
+func (c *EveNgClient) ImportLabs(folder FolderPath, zipData []byte) error {
+	if !c.isValid() {
+		return &NotValidError{}
+	}
+	_, err := c.upload(endpointPath+"import", map[string]string{"path": folder.String()}, "file", "import.zip", "application/zip", zipData)
+	if err != nil {
+		return errors.Wrap(err, "error while importing labs into folder "+folder.String())
+	}
+	return nil
+}
+
+/*
+DownloadLabFile returns the raw .unl file of the given lab
+*/
+func (c *EveNgClient) DownloadLabFile(labPath LabPath) ([]byte, error) {
+	zipData, err := c.ExportLabs(labPath.Folder(), labPath.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "error while exporting lab")
+	}
+	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open export archive")
+	}
+	for _, file := range reader.File {
+		if path.Base(file.Name) != labPath.File() {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open lab file in export archive")
+		}
+		defer f.Close()
+		b, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read lab file from export archive")
+		}
+		return b, nil
+	}
+	return nil, errors.New("export archive does not contain " + labPath.File())
+}
+
+/*
+UploadLabFile uploads a raw .unl file into the given folder. A lab with the same id must not exist on the server.
+*/
+func (c *EveNgClient) UploadLabFile(folder FolderPath, fileName string, data []byte) error {
+	if !strings.HasSuffix(fileName, ".unl") {
+		fileName += ".unl"
+	}
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	f, err := writer.Create(path.Base(fileName))
+	if err != nil {
+		return errors.Wrap(err, "failed to create import archive")
+	}
+	_, err = f.Write(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to write import archive")
+	}
+	err = writer.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to write import archive")
+	}
+	return c.ImportLabs(folder, buf.Bytes())
+}
+
+/*
+GetLabFile downloads and parses the .unl file of the given lab
+*/
+func (c *EveNgClient) GetLabFile(labPath LabPath) (*UNLLab, error) {
+	b, err := c.DownloadLabFile(labPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUNL(b)
+}
+
+/*
+PutLabFile serializes the given lab and uploads it into the given folder
+*/
+func (c *EveNgClient) PutLabFile(folder FolderPath, lab *UNLLab) error {
+	b, err := lab.Marshal()
+	if err != nil {
+		return err
+	}
+	return c.UploadLabFile(folder, lab.Name, b)
+}
+
+/*
+CloneLab copies a lab to a new path. The copy gets a new id and is named after the file name of the new path.
+*/
+func (c *EveNgClient) CloneLab(labPath LabPath, newPath LabPath) error {
+	lab, err := c.GetLabFile(labPath)
+	if err != nil {
+		return errors.Wrap(err, "error while downloading lab")
+	}
+	lab.ID, err = newUUID()
+	if err != nil {
+		return err
+	}
+	lab.Name = newPath.Name()
+	return c.PutLabFile(newPath.Folder(), lab)
+}
+
+//---------- helper functions ----------//
+
+/*
+newUUID - Returns a random version 4 uuid
+*/
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate uuid")
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}